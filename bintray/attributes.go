@@ -0,0 +1,415 @@
+package bintray
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// timeFormat is the ISO 8601 format of the dates sent to Bintray.
+const timeFormat = "2006-01-02T15:04:05.000Z0700"
+
+// timeLayouts are the ISO 8601 formats accepted for dates, with or without
+// milliseconds (parsed anyway by time.Parse) and a colon in the offset.
+var timeLayouts = []string{
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05Z07:00",
+}
+
+// parseTime parses an ISO 8601 date, ie `2011-07-14T19:43:37+0100`.
+func parseTime(s string) (time.Time, error) {
+	var err error
+	for _, layout := range timeLayouts {
+		var t time.Time
+		if t, err = time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// AttributeType is the type of the values of an attribute.
+type AttributeType string
+
+// Attribute types supported by Bintray.
+const (
+	AttributeString  AttributeType = "string"
+	AttributeDate    AttributeType = "date"
+	AttributeNumber  AttributeType = "number"
+	AttributeBoolean AttributeType = "boolean"
+	AttributeVersion AttributeType = "version"
+)
+
+// Attribute is a named list of typed values attached to a package, version or file.
+// If Type is empty, Bintray infers it from the values.
+//
+// Values must match Type: string for AttributeString and AttributeVersion,
+// time.Time or an ISO 8601 string for AttributeDate, any Go number for
+// AttributeNumber and bool for AttributeBoolean.
+// Values returned by Bintray are decoded as string, float64 and bool.
+type Attribute struct {
+	Name   string        `json:"name"`
+	Type   AttributeType `json:"type,omitempty"`
+	Values []interface{} `json:"values"`
+}
+
+// StringAttribute returns an attribute of type string.
+func StringAttribute(name string, values ...string) Attribute {
+	return Attribute{Name: name, Type: AttributeString, Values: stringValues(values)}
+}
+
+// VersionAttribute returns an attribute of type version.
+func VersionAttribute(name string, values ...string) Attribute {
+	return Attribute{Name: name, Type: AttributeVersion, Values: stringValues(values)}
+}
+
+// DateAttribute returns an attribute of type date.
+func DateAttribute(name string, values ...time.Time) Attribute {
+	a := Attribute{Name: name, Type: AttributeDate}
+	for _, v := range values {
+		a.Values = append(a.Values, v)
+	}
+	return a
+}
+
+// NumberAttribute returns an attribute of type number.
+func NumberAttribute(name string, values ...float64) Attribute {
+	a := Attribute{Name: name, Type: AttributeNumber}
+	for _, v := range values {
+		a.Values = append(a.Values, v)
+	}
+	return a
+}
+
+// BooleanAttribute returns an attribute of type boolean.
+func BooleanAttribute(name string, values ...bool) Attribute {
+	a := Attribute{Name: name, Type: AttributeBoolean}
+	for _, v := range values {
+		a.Values = append(a.Values, v)
+	}
+	return a
+}
+
+func stringValues(values []string) []interface{} {
+	result := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		result = append(result, v)
+	}
+	return result
+}
+
+// Validate checks the attribute has a name and values matching its type.
+func (a Attribute) Validate() error {
+	if a.Name == "" {
+		return errors.New("Attribute: name shouldn't be empty")
+	}
+	switch a.Type {
+	case "", AttributeString, AttributeDate, AttributeNumber, AttributeBoolean, AttributeVersion:
+	default:
+		return fmt.Errorf("Attribute %s: unknown type %q", a.Name, a.Type)
+	}
+	for _, v := range a.Values {
+		if !validAttributeValue(a.Type, v) {
+			return fmt.Errorf("Attribute %s: invalid %s value %#v", a.Name, a.Type, v)
+		}
+	}
+	return nil
+}
+
+func validAttributeValue(t AttributeType, v interface{}) bool {
+	switch t {
+	case "":
+		// the type is inferred by Bintray
+		_, isTime := v.(time.Time)
+		return isTime || validAttributeValue(AttributeString, v) || validAttributeValue(AttributeNumber, v) || validAttributeValue(AttributeBoolean, v)
+	case AttributeString, AttributeVersion:
+		_, ok := v.(string)
+		return ok
+	case AttributeDate:
+		switch d := v.(type) {
+		case time.Time:
+			return true
+		case string:
+			_, err := parseTime(d)
+			return err == nil
+		}
+		return false
+	case AttributeNumber:
+		switch v.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, json.Number:
+			return true
+		}
+		return false
+	case AttributeBoolean:
+		_, ok := v.(bool)
+		return ok
+	}
+	return false
+}
+
+// MarshalJSON encodes the attribute, formatting the dates as expected by Bintray.
+func (a Attribute) MarshalJSON() ([]byte, error) {
+	type attribute Attribute
+	values := make([]interface{}, 0, len(a.Values))
+	for _, v := range a.Values {
+		values = append(values, formatAttributeValue(v))
+	}
+	a.Values = values
+	return json.Marshal(attribute(a))
+}
+
+// Times returns the values of a date attribute.
+func (a Attribute) Times() ([]time.Time, error) {
+	times := make([]time.Time, 0, len(a.Values))
+	for _, v := range a.Values {
+		switch d := v.(type) {
+		case time.Time:
+			times = append(times, d)
+		case string:
+			t, err := parseTime(d)
+			if err != nil {
+				return nil, err
+			}
+			times = append(times, t)
+		default:
+			return nil, fmt.Errorf("Attribute %s: invalid date value %#v", a.Name, v)
+		}
+	}
+	return times, nil
+}
+
+func formatAttributeValue(v interface{}) interface{} {
+	if t, ok := v.(time.Time); ok {
+		return t.Format(timeFormat)
+	}
+	return v
+}
+
+// AttributeSearch builds an AttributeQuery: an item must match all the conditions.
+//
+//	query := bintray.NewAttributeSearch().
+//		Equals("os", "linux", "darwin").
+//		Range("downloads", 100, nil).
+//		Query()
+type AttributeSearch struct {
+	query AttributeQuery
+}
+
+// NewAttributeSearch returns an empty AttributeSearch.
+func NewAttributeSearch() *AttributeSearch {
+	return &AttributeSearch{query: AttributeQuery{}}
+}
+
+// Equals matches the items with the attribute equal to any of the given values.
+func (s *AttributeSearch) Equals(name string, values ...interface{}) *AttributeSearch {
+	formatted := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		formatted = append(formatted, formatAttributeValue(v))
+	}
+	s.query = append(s.query, map[string]interface{}{name: formatted})
+	return s
+}
+
+// Range matches the items with the attribute between from and to, both included.
+// A nil bound leaves the range open on that side.
+func (s *AttributeSearch) Range(name string, from, to interface{}) *AttributeSearch {
+	lower, upper := "]*", "*["
+	if from != nil {
+		lower = "[" + fmt.Sprint(formatAttributeValue(from))
+	}
+	if to != nil {
+		upper = fmt.Sprint(formatAttributeValue(to)) + "]"
+	}
+	s.query = append(s.query, map[string]interface{}{name: lower + "," + upper})
+	return s
+}
+
+// Query returns the built query.
+func (s *AttributeSearch) Query() AttributeQuery {
+	return s.query
+}
+
+// GetAttributes returns the attributes of a package or, if version is not empty, of a version.
+// If names are given, only the named attributes are returned.
+// GET /packages/:subject/:repo/:package/attributes
+// GET /packages/:subject/:repo/:package/versions/:version/attributes
+func (c *Client) GetAttributes(subject, repository, pkg, version string, names ...string) ([]Attribute, error) {
+	return c.GetAttributesContext(context.Background(), subject, repository, pkg, version, names...)
+}
+
+// GetAttributesContext is like GetAttributes but uses the given context for the request.
+func (c *Client) GetAttributesContext(ctx context.Context, subject, repository, pkg, version string, names ...string) ([]Attribute, error) {
+	if subject == "" || repository == "" || pkg == "" {
+		return nil, errors.New("GetAttributes: subject, repository and package name shouldn't be empty")
+	}
+	return c.getAttributes(ctx, attributesURL(subject, repository, pkg, version), names)
+}
+
+// SetAttributes replaces all the attributes of a package or, if version is not empty, of a version.
+// POST /packages/:subject/:repo/:package/attributes
+// POST /packages/:subject/:repo/:package/versions/:version/attributes
+func (c *Client) SetAttributes(subject, repository, pkg, version string, attributes []Attribute) error {
+	return c.SetAttributesContext(context.Background(), subject, repository, pkg, version, attributes)
+}
+
+// SetAttributesContext is like SetAttributes but uses the given context for the request.
+func (c *Client) SetAttributesContext(ctx context.Context, subject, repository, pkg, version string, attributes []Attribute) error {
+	if subject == "" || repository == "" || pkg == "" {
+		return errors.New("SetAttributes: subject, repository and package name shouldn't be empty")
+	}
+	return c.writeAttributes(ctx, "POST", attributesURL(subject, repository, pkg, version), attributes)
+}
+
+// UpdateAttributes adds or replaces the given attributes of a package or, if
+// version is not empty, of a version, keeping the other ones.
+// PATCH /packages/:subject/:repo/:package/attributes
+// PATCH /packages/:subject/:repo/:package/versions/:version/attributes
+func (c *Client) UpdateAttributes(subject, repository, pkg, version string, attributes []Attribute) error {
+	return c.UpdateAttributesContext(context.Background(), subject, repository, pkg, version, attributes)
+}
+
+// UpdateAttributesContext is like UpdateAttributes but uses the given context for the request.
+func (c *Client) UpdateAttributesContext(ctx context.Context, subject, repository, pkg, version string, attributes []Attribute) error {
+	if subject == "" || repository == "" || pkg == "" {
+		return errors.New("UpdateAttributes: subject, repository and package name shouldn't be empty")
+	}
+	return c.writeAttributes(ctx, "PATCH", attributesURL(subject, repository, pkg, version), attributes)
+}
+
+// DeleteAttributes deletes the named attributes of a package or, if version is not empty, of a version.
+// Without names all the attributes are deleted.
+// DELETE /packages/:subject/:repo/:package/attributes
+// DELETE /packages/:subject/:repo/:package/versions/:version/attributes
+func (c *Client) DeleteAttributes(subject, repository, pkg, version string, names ...string) error {
+	return c.DeleteAttributesContext(context.Background(), subject, repository, pkg, version, names...)
+}
+
+// DeleteAttributesContext is like DeleteAttributes but uses the given context for the request.
+func (c *Client) DeleteAttributesContext(ctx context.Context, subject, repository, pkg, version string, names ...string) error {
+	if subject == "" || repository == "" || pkg == "" {
+		return errors.New("DeleteAttributes: subject, repository and package name shouldn't be empty")
+	}
+	return c.deleteAttributes(ctx, attributesURL(subject, repository, pkg, version), names)
+}
+
+// GetFileAttributes returns the attributes of a file.
+// If names are given, only the named attributes are returned.
+// GET /file_attributes/:subject/:repo/:file_path
+func (c *Client) GetFileAttributes(subject, repository, filePath string, names ...string) ([]Attribute, error) {
+	return c.GetFileAttributesContext(context.Background(), subject, repository, filePath, names...)
+}
+
+// GetFileAttributesContext is like GetFileAttributes but uses the given context for the request.
+func (c *Client) GetFileAttributesContext(ctx context.Context, subject, repository, filePath string, names ...string) ([]Attribute, error) {
+	if subject == "" || repository == "" || filePath == "" {
+		return nil, errors.New("GetFileAttributes: subject, repository and file path shouldn't be empty")
+	}
+	return c.getAttributes(ctx, fileAttributesURL(subject, repository, filePath), names)
+}
+
+// SetFileAttributes replaces all the attributes of a file.
+// POST /file_attributes/:subject/:repo/:file_path
+func (c *Client) SetFileAttributes(subject, repository, filePath string, attributes []Attribute) error {
+	return c.SetFileAttributesContext(context.Background(), subject, repository, filePath, attributes)
+}
+
+// SetFileAttributesContext is like SetFileAttributes but uses the given context for the request.
+func (c *Client) SetFileAttributesContext(ctx context.Context, subject, repository, filePath string, attributes []Attribute) error {
+	if subject == "" || repository == "" || filePath == "" {
+		return errors.New("SetFileAttributes: subject, repository and file path shouldn't be empty")
+	}
+	return c.writeAttributes(ctx, "POST", fileAttributesURL(subject, repository, filePath), attributes)
+}
+
+// UpdateFileAttributes adds or replaces the given attributes of a file, keeping the other ones.
+// PATCH /file_attributes/:subject/:repo/:file_path
+func (c *Client) UpdateFileAttributes(subject, repository, filePath string, attributes []Attribute) error {
+	return c.UpdateFileAttributesContext(context.Background(), subject, repository, filePath, attributes)
+}
+
+// UpdateFileAttributesContext is like UpdateFileAttributes but uses the given context for the request.
+func (c *Client) UpdateFileAttributesContext(ctx context.Context, subject, repository, filePath string, attributes []Attribute) error {
+	if subject == "" || repository == "" || filePath == "" {
+		return errors.New("UpdateFileAttributes: subject, repository and file path shouldn't be empty")
+	}
+	return c.writeAttributes(ctx, "PATCH", fileAttributesURL(subject, repository, filePath), attributes)
+}
+
+// DeleteFileAttributes deletes the named attributes of a file.
+// Without names all the attributes are deleted.
+// DELETE /file_attributes/:subject/:repo/:file_path
+func (c *Client) DeleteFileAttributes(subject, repository, filePath string, names ...string) error {
+	return c.DeleteFileAttributesContext(context.Background(), subject, repository, filePath, names...)
+}
+
+// DeleteFileAttributesContext is like DeleteFileAttributes but uses the given context for the request.
+func (c *Client) DeleteFileAttributesContext(ctx context.Context, subject, repository, filePath string, names ...string) error {
+	if subject == "" || repository == "" || filePath == "" {
+		return errors.New("DeleteFileAttributes: subject, repository and file path shouldn't be empty")
+	}
+	return c.deleteAttributes(ctx, fileAttributesURL(subject, repository, filePath), names)
+}
+
+// SearchFilesByAttributes returns the files of the repository matching the attributes query.
+// POST /search/file_attributes/:subject/:repo
+func (c *Client) SearchFilesByAttributes(subject, repository string, query AttributeQuery) ([]FileData, error) {
+	return c.SearchFilesByAttributesContext(context.Background(), subject, repository, query)
+}
+
+// SearchFilesByAttributesContext is like SearchFilesByAttributes but uses the given context for the request.
+func (c *Client) SearchFilesByAttributesContext(ctx context.Context, subject, repository string, query AttributeQuery) ([]FileData, error) {
+	if subject == "" || repository == "" || len(query) == 0 {
+		return nil, errors.New("SearchFilesByAttributes: subject, repository and query shouldn't be empty")
+	}
+	files := make([]FileData, 0)
+	if _, err := c.executeJSON(ctx, "POST", "search/file_attributes/"+subject+"/"+repository, query, &files); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+func attributesURL(subject, repository, pkg, version string) string {
+	u := "packages/" + subject + "/" + repository + "/" + pkg
+	if version != "" {
+		u += "/versions/" + version
+	}
+	return u + "/attributes"
+}
+
+func fileAttributesURL(subject, repository, filePath string) string {
+	return "file_attributes/" + subject + "/" + repository + "/" + strings.TrimPrefix(filePath, "/")
+}
+
+func withNames(u string, names []string) string {
+	if len(names) == 0 {
+		return u
+	}
+	return u + "?" + url.Values{"names": {strings.Join(names, ",")}}.Encode()
+}
+
+func (c *Client) getAttributes(ctx context.Context, u string, names []string) ([]Attribute, error) {
+	attributes := make([]Attribute, 0)
+	if _, err := c.executeJSON(ctx, "GET", withNames(u, names), nil, &attributes); err != nil {
+		return nil, err
+	}
+	return attributes, nil
+}
+
+func (c *Client) writeAttributes(ctx context.Context, method, u string, attributes []Attribute) error {
+	for _, a := range attributes {
+		if err := a.Validate(); err != nil {
+			return err
+		}
+	}
+	_, err := c.executeJSON(ctx, method, u, attributes, nil)
+	return err
+}
+
+func (c *Client) deleteAttributes(ctx context.Context, u string, names []string) error {
+	_, err := c.executeJSON(ctx, "DELETE", withNames(u, names), nil, nil)
+	return err
+}
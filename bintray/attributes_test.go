@@ -0,0 +1,213 @@
+package bintray
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAttribute_Validate(t *testing.T) {
+	date := time.Date(2016, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		attribute Attribute
+		valid     bool
+	}{
+		{StringAttribute("os", "linux", "windows"), true},
+		{VersionAttribute("since", "1.2.0"), true},
+		{DateAttribute("released", date), true},
+		{Attribute{Name: "released", Type: AttributeDate, Values: []interface{}{"2016-01-02T03:04:05.000Z"}}, true},
+		{Attribute{Name: "released", Type: AttributeDate, Values: []interface{}{"2011-07-14T19:43:37+0100"}}, true},
+		{Attribute{Name: "released", Type: AttributeDate, Values: []interface{}{"2011-07-14T19:43:37.120+01:00"}}, true},
+		{Attribute{Name: "released", Type: AttributeDate, Values: []interface{}{"2011-07-14"}}, false},
+		{Attribute{Name: "released", Type: AttributeDate, Values: []interface{}{"yesterday"}}, false},
+		{NumberAttribute("downloads", 12), true},
+		{Attribute{Name: "downloads", Type: AttributeNumber, Values: []interface{}{int64(12)}}, true},
+		{Attribute{Name: "downloads", Type: AttributeNumber, Values: []interface{}{"12"}}, false},
+		{BooleanAttribute("stable", true), true},
+		{Attribute{Name: "stable", Type: AttributeBoolean, Values: []interface{}{"true"}}, false},
+		{Attribute{Name: "any", Values: []interface{}{"a", 1, true}}, true},
+		{Attribute{Name: "any", Type: "list", Values: []interface{}{"a"}}, false},
+		{Attribute{Type: AttributeString, Values: []interface{}{"a"}}, false},
+	}
+	for _, tt := range tests {
+		err := tt.attribute.Validate()
+		if tt.valid && err != nil {
+			t.Errorf("%#v: unexpected error %s", tt.attribute, err)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("%#v: expected error", tt.attribute)
+		}
+	}
+}
+
+func TestAttribute_Times(t *testing.T) {
+	tests := map[string]time.Time{
+		"2011-07-14T19:43:37+0100":      time.Date(2011, 7, 14, 18, 43, 37, 0, time.UTC),
+		"2011-07-14T19:43:37+01:00":     time.Date(2011, 7, 14, 18, 43, 37, 0, time.UTC),
+		"2011-07-14T19:43:37.120+0100":  time.Date(2011, 7, 14, 18, 43, 37, 120e6, time.UTC),
+		"2011-07-14T18:43:37.120Z":      time.Date(2011, 7, 14, 18, 43, 37, 120e6, time.UTC),
+		"2011-07-14T19:43:37.120+01:00": time.Date(2011, 7, 14, 18, 43, 37, 120e6, time.UTC),
+	}
+	for value, expected := range tests {
+		times, err := Attribute{Name: "released", Type: AttributeDate, Values: []interface{}{value}}.Times()
+		if err != nil {
+			t.Errorf("%s: unexpected error thrown %s", value, err)
+			continue
+		}
+		if !times[0].Equal(expected) {
+			t.Errorf("%s: Times() = %v, want %v", value, times[0], expected)
+		}
+	}
+}
+
+func TestAttribute_MarshalJSON(t *testing.T) {
+	date := time.Date(2016, 1, 2, 3, 4, 5, 0, time.UTC)
+	data, err := json.Marshal(DateAttribute("released", date))
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	expected := `{"name":"released","type":"date","values":["2016-01-02T03:04:05.000Z"]}`
+	if string(data) != expected {
+		t.Errorf("json %s, want %s", data, expected)
+	}
+	var decoded Attribute
+	json.Unmarshal(data, &decoded)
+	times, err := decoded.Times()
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if len(times) != 1 || !times[0].Equal(date) {
+		t.Errorf("Times() = %v, want %v", times, date)
+	}
+}
+
+func TestAttributeSearch(t *testing.T) {
+	date := time.Date(2016, 1, 2, 3, 4, 5, 0, time.UTC)
+	query := NewAttributeSearch().
+		Equals("os", "linux", "darwin").
+		Range("downloads", 100, nil).
+		Range("released", nil, date).
+		Range("version", "1.0", "2.0").
+		Query()
+	expected := AttributeQuery{
+		{"os": []interface{}{"linux", "darwin"}},
+		{"downloads": "[100,*["},
+		{"released": "]*,2016-01-02T03:04:05.000Z]"},
+		{"version": "[1.0,2.0]"},
+	}
+	if !reflect.DeepEqual(query, expected) {
+		t.Errorf("query = %#v, want %#v", query, expected)
+	}
+}
+
+func TestGetAttributes(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/packages/subject/repository/pkg/versions/1.2/attributes", func(w http.ResponseWriter, r *http.Request) {
+		if m := "GET"; m != r.Method {
+			t.Errorf("Request method = %v, want %v", r.Method, m)
+		}
+		if v := r.URL.Query().Get("names"); v != "os,stable" {
+			t.Errorf("names = %q", v)
+		}
+		fmt.Fprint(w, `[{"name":"os","type":"string","values":["linux"]},{"name":"stable","type":"boolean","values":[true]}]`)
+	})
+	attributes, err := client.GetAttributes("subject", "repository", "pkg", "1.2", "os", "stable")
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	expected := []Attribute{StringAttribute("os", "linux"), BooleanAttribute("stable", true)}
+	if !reflect.DeepEqual(attributes, expected) {
+		t.Errorf("GetAttributes = %#v, want %#v", attributes, expected)
+	}
+}
+
+func TestSetAttributes(t *testing.T) {
+	setup()
+	defer teardown()
+	methods := map[string]bool{}
+	mux.HandleFunc("/packages/subject/repository/pkg/attributes", func(w http.ResponseWriter, r *http.Request) {
+		methods[r.Method] = true
+		body, _ := ioutil.ReadAll(r.Body)
+		if expected := `[{"name":"downloads","type":"number","values":[12]}]`; string(body) != expected {
+			t.Errorf("body %s, want %s", body, expected)
+		}
+		w.WriteHeader(200)
+	})
+	attributes := []Attribute{NumberAttribute("downloads", 12)}
+	if err := client.SetAttributes("subject", "repository", "pkg", "", attributes); err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if err := client.UpdateAttributes("subject", "repository", "pkg", "", attributes); err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if !methods["POST"] || !methods["PATCH"] {
+		t.Errorf("expected POST and PATCH, got %v", methods)
+	}
+	invalid := []Attribute{{Name: "downloads", Type: AttributeNumber, Values: []interface{}{"many"}}}
+	if err := client.SetAttributes("subject", "repository", "pkg", "", invalid); err == nil {
+		t.Errorf("expected error for invalid attribute value")
+	}
+}
+
+func TestDeleteAttributes(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/packages/subject/repository/pkg/versions/1.2/attributes", func(w http.ResponseWriter, r *http.Request) {
+		if m := "DELETE"; m != r.Method {
+			t.Errorf("Request method = %v, want %v", r.Method, m)
+		}
+		if q := r.URL.RawQuery; q != "" {
+			t.Errorf("query = %q, want empty", q)
+		}
+	})
+	if err := client.DeleteAttributes("subject", "repository", "pkg", "1.2"); err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+}
+
+func TestFileAttributes(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/file_attributes/subject/repository/a/file.txt", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `[{"name":"os","type":"string","values":["linux"]}]`)
+		case "PATCH":
+			w.WriteHeader(200)
+		case "DELETE":
+			if v := r.URL.Query().Get("names"); v != "os" {
+				t.Errorf("names = %q", v)
+			}
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+	mux.HandleFunc("/search/file_attributes/subject/repository", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, filesResp)
+	})
+	attributes, err := client.GetFileAttributes("subject", "repository", "/a/file.txt")
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if len(attributes) != 1 || attributes[0].Name != "os" {
+		t.Errorf("unexpected attributes %#v", attributes)
+	}
+	if err := client.UpdateFileAttributes("subject", "repository", "a/file.txt", attributes); err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if err := client.DeleteFileAttributes("subject", "repository", "a/file.txt", "os"); err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	files, err := client.SearchFilesByAttributes("subject", "repository", NewAttributeSearch().Equals("os", "linux").Query())
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if len(files) != 1 {
+		t.Errorf("unexpected files %#v", files)
+	}
+}
@@ -1,14 +1,14 @@
 package bintray
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"slices"
 	"strings"
 	"testing"
-
-	"github.com/enr/go-commons/lang"
 )
 
 const (
@@ -74,7 +74,7 @@ func TestExecute(t *testing.T) {
 		}
 		fmt.Fprint(w, `{"A":"a"}`)
 	})
-	req, err := client.newRequestWithBody("GET", "/", "request_data")
+	req, err := client.newRequestWithBody(context.Background(), "GET", "/", "request_data")
 	if err != nil {
 		t.Errorf("Expected nil error; got %#v.", err)
 	}
@@ -99,7 +99,7 @@ func testHTTPError(t *testing.T, statusCode int) {
 	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Bad Request", statusCode)
 	})
-	req, err := client.newRequestWithBody("GET", "/", "request_data")
+	req, err := client.newRequestWithBody(context.Background(), "GET", "/", "request_data")
 	if err != nil {
 		t.Errorf("Expected nil error; got %#v.", err)
 	}
@@ -156,7 +156,7 @@ func TestGetVersions(t *testing.T) {
 		t.Errorf("expected versions %d but got %d", len(expectedVersions), len(versions))
 	}
 	for _, v := range versions {
-		if !lang.SliceContainsString(expectedVersions, v) {
+		if !slices.Contains(expectedVersions, v) {
 			t.Errorf("versions %s not expected", v)
 		}
 	}
@@ -264,7 +264,7 @@ func TestAuthenticationClient(t *testing.T) {
 	})
 	c := NewClient(nil, "testsub", "testapi")
 	c.BaseURL, _ = url.Parse(server.URL)
-	req, err := c.newRequestWithBody("GET", "/", "request_data")
+	req, err := c.newRequestWithBody(context.Background(), "GET", "/", "request_data")
 	if err != nil {
 		t.Errorf("Expected nil error; got %#v.", err)
 	}
@@ -278,7 +278,7 @@ func TestAuthenticationClient(t *testing.T) {
 func TestNewRequestWithBody(t *testing.T) {
 	c := NewClient(nil, "", "")
 	inURL, outURL := "/foo", defaultBaseURL+"foo"
-	req, _ := c.newRequestWithBody("GET", inURL, "request_body")
+	req, _ := c.newRequestWithBody(context.Background(), "GET", inURL, "request_body")
 	// test that relative URL was expanded
 	if req.URL.String() != outURL {
 		t.Errorf("NewRequestWithBody(%v) URL = %v, want %v", inURL, req.URL, outURL)
@@ -299,7 +299,7 @@ func TestExecute_redirectLoop(t *testing.T) {
 	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		http.Redirect(w, r, "/", http.StatusFound)
 	})
-	req, _ := client.newRequestWithBody("GET", "/", "")
+	req, _ := client.newRequestWithBody(context.Background(), "GET", "/", "")
 	_, err := client.execute(req)
 	if err == nil {
 		t.Error("Expected error to be returned.")
@@ -328,3 +328,21 @@ func testHeader(t *testing.T, r *http.Request, header string, want string) {
 		t.Errorf("Header %s = %s, want: %s", header, value, want)
 	}
 }
+
+func TestContextCancelled(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, respBodyPkg)
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := client.GetVersionsContext(ctx, "subject", "repository", "pkg")
+	if err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %#v", err)
+	}
+	err = client.UploadFileContext(ctx, "subject", "repository", "pkg", "1.2", "", "", "testdata/01.txt", "", false)
+	if err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %#v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package bintray
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+)
+
+// Checksums of a content, hex encoded.
+type Checksums struct {
+	MD5    string
+	SHA1   string
+	SHA256 string
+}
+
+// checksumWriter computes the checksums of the content written to it.
+type checksumWriter struct {
+	md5    hash.Hash
+	sha1   hash.Hash
+	sha256 hash.Hash
+	w      io.Writer
+}
+
+func newChecksumWriter() *checksumWriter {
+	c := &checksumWriter{md5: md5.New(), sha1: sha1.New(), sha256: sha256.New()}
+	c.w = io.MultiWriter(c.md5, c.sha1, c.sha256)
+	return c
+}
+
+func (c *checksumWriter) Write(p []byte) (int, error) {
+	return c.w.Write(p)
+}
+
+func (c *checksumWriter) checksums() *Checksums {
+	return &Checksums{
+		MD5:    hex.EncodeToString(c.md5.Sum(nil)),
+		SHA1:   hex.EncodeToString(c.sha1.Sum(nil)),
+		SHA256: hex.EncodeToString(c.sha256.Sum(nil)),
+	}
+}
+
+// computeChecksums reads r to the end returning its checksums.
+func computeChecksums(r io.Reader) (*Checksums, error) {
+	c := newChecksumWriter()
+	if _, err := io.Copy(c, r); err != nil {
+		return nil, err
+	}
+	return c.checksums(), nil
+}
+
+// sidecars returns the checksum files contents by extension, as expected in Maven repositories.
+func (c *Checksums) sidecars() [][2]string {
+	return [][2]string{{".md5", c.MD5}, {".sha1", c.SHA1}, {".sha256", c.SHA256}}
+}
@@ -0,0 +1,146 @@
+package bintray
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const checksumContent = "checksummed content"
+
+func TestComputeChecksums(t *testing.T) {
+	sums, err := computeChecksums(strings.NewReader(checksumContent))
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	md5Sum := md5.Sum([]byte(checksumContent))
+	sha256Sum := sha256.Sum256([]byte(checksumContent))
+	expected := Checksums{
+		MD5:    hex.EncodeToString(md5Sum[:]),
+		SHA1:   sha1Of(checksumContent),
+		SHA256: hex.EncodeToString(sha256Sum[:]),
+	}
+	if *sums != expected {
+		t.Errorf("checksums %+v, want %+v", *sums, expected)
+	}
+}
+
+// setupChecksums serves an upload endpoint recording the uploaded contents by path
+// and a files list reporting remoteSha1 for a/file.txt, or no files if empty.
+func setupChecksums(remoteSha1 string) (map[string]string, *sync.Mutex) {
+	setup()
+	uploaded := map[string]string{}
+	var mu sync.Mutex
+	mux.HandleFunc("/content/subject/repository/pkg/1.2/", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		path := strings.TrimPrefix(r.URL.Path, "/content/subject/repository/pkg/1.2/")
+		mu.Lock()
+		uploaded[path] = string(body)
+		mu.Unlock()
+		w.WriteHeader(201)
+	})
+	mux.HandleFunc("/packages/subject/repository/pkg/versions/1.2/files", func(w http.ResponseWriter, r *http.Request) {
+		if remoteSha1 == "" {
+			fmt.Fprint(w, `[]`)
+			return
+		}
+		fmt.Fprint(w, `[{"name":"file.txt","path":"a/file.txt","sha1":"`+remoteSha1+`"}]`)
+	})
+	return uploaded, &mu
+}
+
+func TestUpload_verifyChecksums(t *testing.T) {
+	setupChecksums(sha1Of(checksumContent))
+	defer teardown()
+	result, err := client.Upload(context.Background(), &UploadRequest{
+		Subject: "subject", Repository: "repository", Package: "pkg", Version: "1.2",
+		Path:    "a/file.txt",
+		Body:    strings.NewReader(checksumContent),
+		Options: UploadOptions{VerifyChecksums: true},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if result.Checksums == nil || result.Checksums.SHA1 != sha1Of(checksumContent) {
+		t.Errorf("unexpected checksums %+v", result.Checksums)
+	}
+}
+
+func TestUpload_verifyChecksumsMissingFile(t *testing.T) {
+	setupChecksums("")
+	defer teardown()
+	_, err := client.Upload(context.Background(), &UploadRequest{
+		Subject: "subject", Repository: "repository", Package: "pkg", Version: "1.2",
+		Path:    "a/file.txt",
+		Body:    strings.NewReader(checksumContent),
+		Options: UploadOptions{VerifyChecksums: true},
+	})
+	var checksumError *ChecksumError
+	if !IsNotFound(err) || errors.As(err, &checksumError) {
+		t.Errorf("expected not found error, got %#v", err)
+	}
+}
+
+func TestUpload_verifyChecksumsMismatch(t *testing.T) {
+	setupChecksums(sha1Of("other content"))
+	defer teardown()
+	pr, pw := io.Pipe()
+	go func() {
+		io.WriteString(pw, checksumContent)
+		pw.Close()
+	}()
+	_, err := client.Upload(context.Background(), &UploadRequest{
+		Subject: "subject", Repository: "repository", Package: "pkg", Version: "1.2",
+		Path:    "a/file.txt",
+		Body:    pr,
+		Options: UploadOptions{VerifyChecksums: true},
+	})
+	var checksumError *ChecksumError
+	if !errors.As(err, &checksumError) {
+		t.Fatalf("expected ChecksumError, got %#v", err)
+	}
+	if checksumError.Expected != sha1Of(checksumContent) {
+		t.Errorf("expected sha1 %q", checksumError.Expected)
+	}
+}
+
+func TestUpload_checksumFiles(t *testing.T) {
+	uploaded, mu := setupChecksums("")
+	defer teardown()
+	pr, pw := io.Pipe()
+	go func() {
+		io.WriteString(pw, checksumContent)
+		pw.Close()
+	}()
+	result, err := client.Upload(context.Background(), &UploadRequest{
+		Subject: "subject", Repository: "repository", Package: "pkg", Version: "1.2",
+		Path:    "a/file.txt",
+		Body:    pr,
+		Options: UploadOptions{ChecksumFiles: true},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	expected := map[string]string{
+		"a/file.txt":        checksumContent,
+		"a/file.txt.md5":    result.Checksums.MD5,
+		"a/file.txt.sha1":   sha1Of(checksumContent),
+		"a/file.txt.sha256": result.Checksums.SHA256,
+	}
+	for path, content := range expected {
+		if uploaded[path] != content {
+			t.Errorf("%s uploaded content %q, want %q", path, uploaded[path], content)
+		}
+	}
+}
@@ -2,17 +2,19 @@ package bintray
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
-
-	"github.com/enr/go-commons/lang"
+	"sync"
+	"time"
 )
 
 // A Client manages communication with the Bintray API.
@@ -31,6 +33,31 @@ type Client struct {
 	// User agent used when communicating with the Bintray API.
 	UserAgent string
 
+	// RetryPolicy used for failed requests. If nil, requests are never retried.
+	RetryPolicy *RetryPolicy
+
+	// CredentialsProvider, if not nil, is consulted for every request and
+	// takes precedence over the subject and API key given to NewClient, which
+	// are used if it returns ErrNoCredentials.
+	CredentialsProvider CredentialsProvider
+
+	// Logger receives an event for every request sent. Defaults to NopLogger.
+	Logger Logger
+
+	// WaitOnRateLimit makes the client block until the rate limit window resets,
+	// when the last response reported no remaining requests, instead of sending
+	// requests bound to fail with 429.
+	WaitOnRateLimit bool
+
+	// RateLimitWindow is the wait used by WaitOnRateLimit when the response
+	// tells neither the reset time nor Retry-After, as Bintray usually does.
+	// Defaults to DefaultRateLimitWindow.
+	RateLimitWindow time.Duration
+
+	rateMu   sync.Mutex
+	rate     Rate
+	rateSeen time.Time
+
 	downloadsHost string
 }
 
@@ -54,24 +81,32 @@ func NewClient(httpClient *http.Client, subject, apikey string) *Client {
 		httpClient = http.DefaultClient
 	}
 	baseURL, _ := url.Parse(defaultBaseURL)
-	c := &Client{client: httpClient, BaseURL: baseURL, UserAgent: userAgent, subject: subject, apikey: apikey}
+	c := &Client{client: httpClient, BaseURL: baseURL, UserAgent: userAgent, subject: subject, apikey: apikey, downloadsHost: defaultDownloadHost, Logger: NopLogger{}}
 	return c
 }
 
 // PackageExists returns if a given package is present in the repository.
 // GET /packages/:subject/:repo/:package
 func (c *Client) PackageExists(subject, repository, pkg string) (bool, error) {
+	return c.PackageExistsContext(context.Background(), subject, repository, pkg)
+}
+
+// PackageExistsContext is like PackageExists but uses the given context for the request.
+func (c *Client) PackageExistsContext(ctx context.Context, subject, repository, pkg string) (bool, error) {
 	if subject == "" || repository == "" || pkg == "" {
 		return false, errors.New("PackageExists: subject, repository and package name shouldn't be empty!")
 	}
-	url := "/packages/" + subject + "/" + repository + "/" + pkg
-	req, err := c.newRequestWithReader("GET", url, nil, 0)
+	url := "packages/" + subject + "/" + repository + "/" + pkg
+	req, err := c.newRequestWithReader(ctx, "GET", url, nil, 0)
 	if err != nil {
 		return false, err
 	}
 	resp, err := c.execute(req)
 	// we consider 404 an acceptable error in this case.
-	if err, ok := err.(*ErrorResponse); ok && err.Response.StatusCode != http.StatusNotFound {
+	if IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
 		return false, err
 	}
 	return (resp.StatusCode == 200), nil
@@ -79,33 +114,46 @@ func (c *Client) PackageExists(subject, repository, pkg string) (bool, error) {
 
 // GetVersions returns all versions for the given package.
 func (c *Client) GetVersions(subject, repository, pkg string) ([]string, error) {
+	return c.GetVersionsContext(context.Background(), subject, repository, pkg)
+}
+
+// GetVersionsContext is like GetVersions but uses the given context for the request.
+func (c *Client) GetVersionsContext(ctx context.Context, subject, repository, pkg string) ([]string, error) {
 	if subject == "" || repository == "" || pkg == "" {
 		return nil, errors.New("GetVersions: subject, repository and package name shouldn't be empty!")
 	}
-	url := "/packages/" + subject + "/" + repository + "/" + pkg
-	req, err := c.newRequestWithReader("GET", url, nil, 0)
+	url := "packages/" + subject + "/" + repository + "/" + pkg
+	req, err := c.newRequestWithReader(ctx, "GET", url, nil, 0)
 	if err != nil {
 		return nil, err
 	}
 	resp, err := c.execute(req)
-
 	if err != nil {
-		fmt.Printf("Error calling %s - %v", url, err)
 		return nil, err
 	}
 	body, err := resp.BodyAsBytes()
 	if err != nil {
 		return nil, err
 	}
-	versions, err := lang.ExtractJSONFieldValue(body, "versions")
-	if err != nil {
+	p := struct {
+		Versions []string `json:"versions"`
+	}{}
+	if err := json.Unmarshal(body, &p); err != nil {
 		return nil, err
 	}
-	return lang.JSONArrayToStringSlice(versions, "versions")
+	if p.Versions == nil {
+		return nil, errors.New("GetVersions: versions not found")
+	}
+	return p.Versions, nil
 }
 
 // GetFilesInfoList returns a FileData struct for each file in the specified version
-func (c *Client) GetFilesInfoList(subject , repository, pkg, version string, includeUnpublished bool) ([]FileData, error) {
+func (c *Client) GetFilesInfoList(subject, repository, pkg, version string, includeUnpublished bool) ([]FileData, error) {
+	return c.GetFilesInfoListContext(context.Background(), subject, repository, pkg, version, includeUnpublished)
+}
+
+// GetFilesInfoListContext is like GetFilesInfoList but uses the given context for the request.
+func (c *Client) GetFilesInfoListContext(ctx context.Context, subject, repository, pkg, version string, includeUnpublished bool) ([]FileData, error) {
 	if subject == "" || repository == "" || pkg == "" || version == "" {
 		return nil, errors.New("GetVersions: subject, repository, package name and version shouldn't be empty")
 	}
@@ -113,8 +161,8 @@ func (c *Client) GetFilesInfoList(subject , repository, pkg, version string, inc
 	if includeUnpublished {
 		unpublished = "?include_unpublished=1"
 	}
-	url := fmt.Sprintf("/packages/%s/%s/%s/versions/%s/files%s", subject, repository, pkg, version, unpublished)
-	req, err := c.newRequestWithReader("GET", url, nil, 0)
+	url := fmt.Sprintf("packages/%s/%s/%s/versions/%s/files%s", subject, repository, pkg, version, unpublished)
+	req, err := c.newRequestWithReader(ctx, "GET", url, nil, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -134,9 +182,27 @@ func (c *Client) GetFilesInfoList(subject , repository, pkg, version string, inc
 	return data, nil
 }
 
+// GetFilesInfoIter returns an iterator over the files in the specified version.
+func (c *Client) GetFilesInfoIter(ctx context.Context, subject, repository, pkg, version string, includeUnpublished bool) *Iterator[FileData] {
+	if subject == "" || repository == "" || pkg == "" || version == "" {
+		return errorIterator[FileData](errors.New("GetFilesInfoList: subject, repository, package name and version shouldn't be empty"))
+	}
+	params := url.Values{}
+	if includeUnpublished {
+		params.Set("include_unpublished", "1")
+	}
+	u := fmt.Sprintf("packages/%s/%s/%s/versions/%s/files", subject, repository, pkg, version)
+	return NewIterator[FileData](ctx, c, u, params)
+}
+
 // GetFilesList returns the list of files for a specific version
-func (c *Client) GetFilesList(subject , repository, pkg, version string, includeUnpublished bool) ([]string, error) {
-	data, err := c.GetFilesInfoList(subject, repository, pkg, version, includeUnpublished)
+func (c *Client) GetFilesList(subject, repository, pkg, version string, includeUnpublished bool) ([]string, error) {
+	return c.GetFilesListContext(context.Background(), subject, repository, pkg, version, includeUnpublished)
+}
+
+// GetFilesListContext is like GetFilesList but uses the given context for the request.
+func (c *Client) GetFilesListContext(ctx context.Context, subject, repository, pkg, version string, includeUnpublished bool) ([]string, error) {
+	data, err := c.GetFilesInfoListContext(ctx, subject, repository, pkg, version, includeUnpublished)
 	if err != nil {
 		return nil, err
 	}
@@ -149,49 +215,108 @@ func (c *Client) GetFilesList(subject , repository, pkg, version string, include
 
 // CreateVersionWithMeta creates a new version adding metadata.
 //POST /packages/:subject/:repo/:package/versions
+//
+// Deprecated: use CreateVersionWithOptions.
 func (c *Client) CreateVersionWithMeta(subject, repository, pkg, version string, reqJSON map[string]interface{}) error {
-	return c.executeCreateVersion(subject, repository, pkg, version, reqJSON)
+	return c.CreateVersionWithMetaContext(context.Background(), subject, repository, pkg, version, reqJSON)
+}
+
+// CreateVersionWithMetaContext is like CreateVersionWithMeta but uses the given context for the request.
+//
+// Deprecated: use CreateVersionWithOptionsContext.
+func (c *Client) CreateVersionWithMetaContext(ctx context.Context, subject, repository, pkg, version string, reqJSON map[string]interface{}) error {
+	if _, vnameExists := reqJSON["name"]; !vnameExists {
+		return errors.New("create version: metadata must contain the name key")
+	}
+	return c.executeCreateVersion(ctx, subject, repository, pkg, version, reqJSON, nil)
 }
 
 // CreateVersion creates new version for a package.
 //POST /packages/:subject/:repo/:package/versions
 func (c *Client) CreateVersion(subject, repository, pkg, version string) error {
-	reqJSON := map[string]interface{}{"name": version}
-	return c.executeCreateVersion(subject, repository, pkg, version, reqJSON)
+	return c.CreateVersionContext(context.Background(), subject, repository, pkg, version)
+}
+
+// CreateVersionContext is like CreateVersion but uses the given context for the request.
+func (c *Client) CreateVersionContext(ctx context.Context, subject, repository, pkg, version string) error {
+	_, err := c.CreateVersionWithOptionsContext(ctx, subject, repository, pkg, &CreateVersionOptions{Name: version})
+	return err
 }
 
-func (c *Client) executeCreateVersion(subject, repository, pkg, version string, reqJSON map[string]interface{}) error {
+func (c *Client) executeCreateVersion(ctx context.Context, subject, repository, pkg, version string, reqJSON interface{}, v interface{}) error {
 	if subject == "" || repository == "" || pkg == "" || version == "" {
 		return errors.New("create version: subject, repository, package name and version shouldn't be empty")
 	}
-	if _, vnameExists := reqJSON["name"]; !vnameExists {
-		return errors.New("create version: metadata must contain the name key")
-	}
-	requestData, err := json.Marshal(reqJSON)
-	if err != nil {
-		return err
-	}
-	url := "/packages/" + subject + "/" + repository + "/" + pkg + "/versions"
-	req, err := c.newRequestWithBody("POST", url, string(requestData))
-	if err != nil {
-		return err
-	}
-	_, err = c.execute(req)
+	url := "packages/" + subject + "/" + repository + "/" + pkg + "/versions"
+	_, err := c.executeJSON(ctx, "POST", url, reqJSON, v)
 	return err
 }
 
+// UploadFileOptions specifies the optional parameters to UploadFileWithOptions.
+type UploadFileOptions struct {
+	// Conflict is the policy applied when a file with the same path already exists.
+	// A file skipped with ConflictSkipIdentical is not sent and gives no error.
+	Conflict ConflictPolicy
+
+	// Progress, if not nil, is notified while the file is sent.
+	Progress ProgressReporter
+}
+
 // UploadFile uploads a file into `/content/:subject/:repo/:package/:version/:path`.
+// An already existing file gives an error matching IsConflict: use
+// UploadFileWithOptions to skip identical files or override them.
+// For Maven repositories UploadMaven handles classifiers, POMs and checksums.
 func (c *Client) UploadFile(subject, repository, pkg, version, projectGroupID, projectName, filePath, extraArgs string, mavenRepo bool) error {
+	return c.UploadFileContext(context.Background(), subject, repository, pkg, version, projectGroupID, projectName, filePath, extraArgs, mavenRepo)
+}
+
+// UploadFileContext is like UploadFile but uses the given context for the request.
+// Cancelling the context aborts the upload.
+func (c *Client) UploadFileContext(ctx context.Context, subject, repository, pkg, version, projectGroupID, projectName, filePath, extraArgs string, mavenRepo bool) error {
+	return c.UploadFileWithOptionsContext(ctx, subject, repository, pkg, version, projectGroupID, projectName, filePath, extraArgs, mavenRepo, nil)
+}
+
+// UploadFileWithOptions is like UploadFile, with the given options.
+func (c *Client) UploadFileWithOptions(subject, repository, pkg, version, projectGroupID, projectName, filePath, extraArgs string, mavenRepo bool, opts *UploadFileOptions) error {
+	return c.UploadFileWithOptionsContext(context.Background(), subject, repository, pkg, version, projectGroupID, projectName, filePath, extraArgs, mavenRepo, opts)
+}
+
+// UploadFileWithOptionsContext is like UploadFileWithOptions but uses the given context for the request.
+func (c *Client) UploadFileWithOptionsContext(ctx context.Context, subject, repository, pkg, version, projectGroupID, projectName, filePath, extraArgs string, mavenRepo bool, opts *UploadFileOptions) error {
+	if opts == nil {
+		opts = &UploadFileOptions{}
+	}
 	fullPath, _ := filepath.Abs(filePath)
 	var entityPath string
 	var uploadURL string
+	// remotePath is the path of the file in the version
+	var remotePath string
 	fileName := filepath.Base(fullPath)
 	if mavenRepo {
 		entityPath = strings.Replace(projectGroupID, ".", "/", -1) + "/" + projectName + "/" + version + "/" + fileName
 		uploadURL = "content/" + subject + "/" + repository + "/" + pkg + "/" + version + "/" + entityPath
+		remotePath = entityPath
 	} else {
 		entityPath = version + "/" + fileName
 		uploadURL = "content/" + subject + "/" + repository + "/" + pkg + "/" + entityPath + extraArgs
+		remotePath = fileName
+	}
+	switch opts.Conflict {
+	case ConflictOverride:
+		if strings.Contains(uploadURL, "?") {
+			uploadURL += "&override=1"
+		} else {
+			uploadURL += "?override=1"
+		}
+	case ConflictSkipIdentical:
+		sha1, err := fileSha1(fullPath)
+		if err != nil {
+			return err
+		}
+		identical, err := c.identicalRemoteFile(ctx, subject, repository, pkg, version, remotePath, sha1)
+		if identical || err != nil {
+			return err
+		}
 	}
 	file, err := os.Open(fullPath)
 	if err != nil {
@@ -203,59 +328,112 @@ func (c *Client) UploadFile(subject, repository, pkg, version, projectGroupID, p
 		return err
 	}
 
-	req, err := c.newRequestWithReader("PUT", uploadURL, file, fi.Size())
+	body := newProgressReader(file, 0, fi.Size(), opts.Progress)
+	req, err := c.newRequestWithReader(ctx, "PUT", uploadURL, body, fi.Size())
 	if err != nil {
 		return err
 	}
 	_, err = c.execute(req)
-	/*
-		if serr, ok := err.(httpError); ok {
-			if serr.statusCode == 409 {
-				//conflict. skip
-				//continue but dont publish.
-				//TODO - provide an option to replace existing artifact
-				//TODO - ?check exists before attempting upload?
-			} else {
-
-			}
-		} else if err != nil {
-
-		}
-	*/
 	return err
 }
 
 // Publish an uploaded file.
 func (c *Client) Publish(subject, repository, pkg, version string) error {
-	if subject == "" || repository == "" || pkg == "" || version == "" {
-		return errors.New("Publish: subject, repository, package name and version shouldn't be empty!")
-	}
-	url := "/content/" + subject + "/" + repository + "/" + pkg + "/" + version + "/publish"
-	req, err := c.newRequestWithReader("POST", url, nil, 0)
-	if err != nil {
-		return err
-	}
-	resp, err := c.execute(req)
-	var objmap map[string]*json.RawMessage
-	jsonBlob, _ := resp.BodyAsBytes()
-	err = json.Unmarshal(jsonBlob, &objmap)
-	if err != nil {
-		fmt.Println("error:", err)
+	return c.PublishContext(context.Background(), subject, repository, pkg, version)
+}
+
+// PublishContext is like Publish but uses the given context for the request.
+func (c *Client) PublishContext(ctx context.Context, subject, repository, pkg, version string) error {
+	_, err := c.PublishWithOptionsContext(ctx, subject, repository, pkg, version, nil)
+	return err
+}
+
+// Rate returns the rate limit reported by the last API response.
+func (c *Client) Rate() Rate {
+	c.rateMu.Lock()
+	defer c.rateMu.Unlock()
+	return c.rate
+}
+
+// waitRateLimit blocks until the rate limit window resets, if the last known rate
+// has no remaining requests. Without a reset time the client waits RateLimitWindow
+// from the response reporting the exhausted limit.
+func (c *Client) waitRateLimit(ctx context.Context) error {
+	c.rateMu.Lock()
+	rate, seen := c.rate, c.rateSeen
+	c.rateMu.Unlock()
+	if rate.Limit == 0 || rate.Remaining > 0 {
+		return nil
 	}
-	filesNum := 0
-	if _, ok := objmap["files"]; ok {
-		//var filesNum int
-		err = json.Unmarshal(*objmap["files"], &filesNum)
-		//fmt.Printf("files filesNum=%d\n", filesNum)
+	reset := rate.Reset
+	if reset.IsZero() {
+		window := c.RateLimitWindow
+		if window <= 0 {
+			window = DefaultRateLimitWindow
+		}
+		reset = seen.Add(window)
 	}
-	// return (filesNum > 0), err
-	return err
+	return sleepContext(ctx, time.Until(reset))
 }
 
 // execute sends an API request and returns the API response and error if any.
+// If the request context is cancelled or expired, its error is returned.
+// Failed requests are retried following the Client RetryPolicy.
 func (c *Client) execute(req *http.Request) (*Response, error) {
-	resp, err := c.client.Do(req)
+	var resp *http.Response
+	var err error
+	for attempt := 1; ; attempt++ {
+		if c.WaitOnRateLimit {
+			if err := c.waitRateLimit(req.Context()); err != nil {
+				return nil, err
+			}
+		}
+		started := time.Now()
+		resp, err = c.client.Do(req)
+		event := RequestEvent{
+			Method:        req.Method,
+			URL:           req.URL.Redacted(),
+			Path:          req.URL.Path,
+			Duration:      time.Since(started),
+			BytesSent:     req.ContentLength,
+			BytesReceived: -1,
+			Attempt:       attempt,
+			Err:           err,
+		}
+		if req.ContentLength == 0 && req.Body != nil && req.Body != http.NoBody {
+			// chunked upload
+			event.BytesSent = -1
+		}
+		if resp != nil {
+			event.Status = resp.StatusCode
+			event.BytesReceived = resp.ContentLength
+			c.updateRate(resp)
+		}
+		_, apikey, _ := req.BasicAuth()
+		c.logRequest(event, apikey)
+		if !c.RetryPolicy.shouldRetry(req, attempt, resp, err) {
+			break
+		}
+		wait := c.RetryPolicy.backoff(attempt, resp)
+		if resp != nil {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if err := sleepContext(req.Context(), wait); err != nil {
+			return nil, err
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+	}
 	if err != nil {
+		if ctxErr := req.Context().Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
 		return nil, err
 	}
 	response := newResponse(resp)
@@ -266,48 +444,143 @@ func (c *Client) execute(req *http.Request) (*Response, error) {
 	return response, err
 }
 
+// executeJSON sends an API request with body, if not nil, encoded as JSON and
+// decodes the JSON response into v, if not nil.
+func (c *Client) executeJSON(ctx context.Context, method, urlStr string, body interface{}, v interface{}) (*Response, error) {
+	requestData := ""
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		requestData = string(data)
+	}
+	req, err := c.newRequestWithBody(ctx, method, urlStr, requestData)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.execute(req)
+	if err != nil {
+		return resp, err
+	}
+	defer resp.Body.Close()
+	if v != nil {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil && err != io.EOF {
+			return resp, err
+		}
+	}
+	return resp, nil
+}
+
+// updateRate stores the rate limit of the response, if it has rate limit headers.
+func (c *Client) updateRate(resp *http.Response) {
+	rate := parseRate(resp)
+	if rate.Limit == 0 {
+		return
+	}
+	c.rateMu.Lock()
+	c.rate = rate
+	c.rateSeen = time.Now()
+	c.rateMu.Unlock()
+}
+
 // newRequestWithBody creates an API request using the given string as the body.
 // A relative URL can be provided in urlStr, in which case it is resolved relative to the BaseURL of the Client.
 // Relative URLs should always be specified without a preceding slash.
-func (c *Client) newRequestWithBody(method, urlStr, body string) (*http.Request, error) {
+func (c *Client) newRequestWithBody(ctx context.Context, method, urlStr, body string) (*http.Request, error) {
 	requestData := []byte(body)
 	requestLength := int64(len(requestData))
 	requestReader := bytes.NewReader(requestData)
 
-	return c.newRequestWithReader(method, urlStr, requestReader, requestLength)
+	return c.newRequestWithReader(ctx, method, urlStr, requestReader, requestLength)
 }
 
-// newRequestWithReader creates an API request.
+// newRequestWithReader creates an API request bound to the given context.
 // A relative URL can be provided in urlStr, in which case it is resolved relative to the BaseURL of the Client.
 // Relative URLs should always be specified without a preceding slash.
-func (c *Client) newRequestWithReader(method, urlStr string, requestReader io.Reader, requestLength int64) (*http.Request, error) {
+func (c *Client) newRequestWithReader(ctx context.Context, method, urlStr string, requestReader io.Reader, requestLength int64) (*http.Request, error) {
 	rel, err := url.Parse(urlStr)
 	if err != nil {
 		return nil, err
 	}
 	u := c.BaseURL.ResolveReference(rel)
-	req, err := http.NewRequest(method, u.String(), requestReader)
+	return c.newRequest(ctx, method, u, requestReader, requestLength)
+}
+
+// newRequest creates a request to the given absolute URL, adding the client
+// user agent and credentials.
+func (c *Client) newRequest(ctx context.Context, method string, u *url.URL, requestReader io.Reader, requestLength int64) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), requestReader)
 	if err != nil {
 		return nil, err
 	}
+	// make seekable bodies (ie files being uploaded) rewindable, so that the
+	// request can be retried sending the full payload
+	if seeker, ok := requestReader.(io.Seeker); ok && req.GetBody == nil {
+		start, err := seeker.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, err
+		}
+		// the http client closes the body after each attempt: the caller owns the reader
+		req.Body = ioutil.NopCloser(requestReader)
+		req.GetBody = func() (io.ReadCloser, error) {
+			if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+				return nil, err
+			}
+			return ioutil.NopCloser(requestReader), nil
+		}
+	}
 	if requestLength > 0 {
 		req.ContentLength = int64(requestLength)
 	}
 	req.Header.Add("User-Agent", c.UserAgent)
-	if c.subject != "" {
-		req.SetBasicAuth(c.subject, c.apikey)
+	subject, apikey := c.subject, c.apikey
+	if c.CredentialsProvider != nil {
+		credentials, err := c.CredentialsProvider.Credentials(ctx)
+		switch {
+		case err == nil:
+			subject, apikey = credentials.Subject, credentials.APIKey
+		case !errors.Is(err, ErrNoCredentials):
+			return nil, err
+		}
+	}
+	if subject != "" {
+		req.SetBasicAuth(subject, apikey)
 	}
 	return req, nil
 }
 
+// Bool returns a pointer to v, to set the optional boolean fields of the
+// requests, ie Package.PublicStats.
+func Bool(v bool) *bool {
+	return &v
+}
+
+// maxErrorBodySize limits the error body read in memory: proxies can return
+// large HTML pages.
+const maxErrorBodySize = 1 << 20
+
 // CheckResponse checks the API response for errors, and returns them if
 // present.  A response is considered an error if it has a status code outside
 // the 200 range.
+// The JSON error body, if any, is decoded into the returned ErrorResponse and
+// the response body is left readable for callers wanting to inspect it further.
+// Only the first maxErrorBodySize bytes of the body are kept.
 func CheckResponse(r *http.Response) error {
 	c := r.StatusCode
 	if 200 <= c && c <= 299 {
 		return nil
 	}
 	errorResponse := &ErrorResponse{Response: r}
+	data, err := ioutil.ReadAll(io.LimitReader(r.Body, maxErrorBodySize))
+	r.Body.Close()
+	if err == nil && len(data) > 0 {
+		// the body could be not JSON (ie from a proxy): keep the status only
+		json.Unmarshal(data, errorResponse)
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(data))
 	return errorResponse
 }
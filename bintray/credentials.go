@@ -0,0 +1,284 @@
+package bintray
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// EnvUser is the environment variable holding the Bintray subject.
+	EnvUser = "BINTRAY_USER"
+	// EnvAPIKey is the environment variable holding the Bintray API key.
+	EnvAPIKey = "BINTRAY_API_KEY"
+	// envKey is the API key variable used by JFrog CLI, used as fallback.
+	envKey = "BINTRAY_KEY"
+
+	defaultNetrcMachine = "api.bintray.com"
+)
+
+// ErrNoCredentials is returned by a CredentialsProvider not finding any credentials.
+// If the Client provider returns it, the subject and API key given to NewClient
+// are used, if any, otherwise requests are sent without authentication.
+var ErrNoCredentials = errors.New("bintray: no credentials found")
+
+// Credentials are the subject and API key used to authenticate to Bintray.
+type Credentials struct {
+	Subject string
+	APIKey  string
+}
+
+// A CredentialsProvider returns the credentials used to authenticate a request.
+// It is called for every request, so implementations reading files should cache the result.
+type CredentialsProvider interface {
+	Credentials(ctx context.Context) (Credentials, error)
+}
+
+// StaticProvider returns always the same credentials.
+type StaticProvider Credentials
+
+// Credentials returns the static credentials.
+func (s StaticProvider) Credentials(ctx context.Context) (Credentials, error) {
+	if s.Subject == "" {
+		return Credentials{}, ErrNoCredentials
+	}
+	return Credentials(s), nil
+}
+
+// EnvProvider reads the credentials from the BINTRAY_USER and BINTRAY_API_KEY
+// environment variables. BINTRAY_KEY is used if BINTRAY_API_KEY is not set.
+type EnvProvider struct{}
+
+// Credentials returns the credentials found in the environment.
+func (EnvProvider) Credentials(ctx context.Context) (Credentials, error) {
+	c := Credentials{Subject: os.Getenv(EnvUser), APIKey: os.Getenv(EnvAPIKey)}
+	if c.APIKey == "" {
+		c.APIKey = os.Getenv(envKey)
+	}
+	if c.Subject == "" || c.APIKey == "" {
+		return Credentials{}, ErrNoCredentials
+	}
+	return c, nil
+}
+
+// NetrcProvider reads the credentials from a netrc file, using login as subject
+// and password as API key.
+// The file is read at every call: wrap it with NewCachedProvider to read it once.
+type NetrcProvider struct {
+	// Path of the netrc file. If empty, $NETRC or ~/.netrc (~/_netrc on Windows) is used.
+	Path string
+	// Machine to look for. If empty, api.bintray.com is used.
+	Machine string
+}
+
+// Credentials returns the credentials found in the netrc file.
+func (n NetrcProvider) Credentials(ctx context.Context) (Credentials, error) {
+	path := n.Path
+	if path == "" {
+		path = defaultNetrcPath()
+	}
+	machine := n.Machine
+	if machine == "" {
+		machine = defaultNetrcMachine
+	}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Credentials{}, ErrNoCredentials
+	}
+	if err != nil {
+		return Credentials{}, err
+	}
+	c, found := parseNetrc(string(data), machine)
+	if !found || c.Subject == "" || c.APIKey == "" {
+		return Credentials{}, ErrNoCredentials
+	}
+	return c, nil
+}
+
+func defaultNetrcPath() string {
+	if p := os.Getenv("NETRC"); p != "" {
+		return p
+	}
+	home, _ := os.UserHomeDir()
+	if runtime.GOOS == "windows" {
+		return filepath.Join(home, "_netrc")
+	}
+	return filepath.Join(home, ".netrc")
+}
+
+// parseNetrc returns the login and password of the given machine,
+// or of the default entry if the machine is not found.
+func parseNetrc(data, machine string) (Credentials, bool) {
+	var (
+		current     *Credentials
+		matched     Credentials
+		def         Credentials
+		found       bool
+		defaultSeen bool
+		inMacdef    bool
+	)
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if inMacdef {
+			// macro definitions end with an empty line
+			inMacdef = strings.TrimSpace(line) != ""
+			continue
+		}
+		fields := strings.Fields(line)
+		for i := 0; i < len(fields); i++ {
+			next := func() string {
+				if i+1 < len(fields) {
+					i++
+					return fields[i]
+				}
+				return ""
+			}
+			switch fields[i] {
+			case "machine":
+				current = nil
+				if name := next(); name == machine && !found {
+					found = true
+					current = &matched
+				}
+			case "default":
+				current = nil
+				if !defaultSeen {
+					defaultSeen = true
+					current = &def
+				}
+			case "login":
+				if v := next(); current != nil {
+					current.Subject = v
+				}
+			case "password":
+				if v := next(); current != nil {
+					current.APIKey = v
+				}
+			case "account":
+				next()
+			case "macdef":
+				next()
+				inMacdef = true
+				i = len(fields)
+			}
+		}
+	}
+	if found {
+		return matched, true
+	}
+	return def, defaultSeen
+}
+
+// ConfigFileProvider reads the credentials from a JSON or YAML file in the
+// JFrog CLI format:
+//
+//	{"bintray": {"user": "subject", "key": "apikey"}}
+//
+// The format is chosen by the file extension: .yaml and .yml files are read as YAML.
+// The file is read at every call: wrap it with NewCachedProvider to read it once.
+type ConfigFileProvider struct {
+	// Path of the config file. If empty, ~/.jfrog/jfrog-cli.conf is used.
+	Path string
+}
+
+type configFile struct {
+	Bintray struct {
+		User string `json:"user" yaml:"user"`
+		Key  string `json:"key" yaml:"key"`
+	} `json:"bintray" yaml:"bintray"`
+}
+
+// Credentials returns the credentials found in the config file.
+func (f ConfigFileProvider) Credentials(ctx context.Context) (Credentials, error) {
+	path := f.Path
+	if path == "" {
+		home, _ := os.UserHomeDir()
+		path = filepath.Join(home, ".jfrog", "jfrog-cli.conf")
+	}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Credentials{}, ErrNoCredentials
+	}
+	if err != nil {
+		return Credentials{}, err
+	}
+	var conf configFile
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &conf)
+	default:
+		err = json.Unmarshal(data, &conf)
+	}
+	if err != nil {
+		return Credentials{}, fmt.Errorf("bintray: error reading %s: %v", path, err)
+	}
+	if conf.Bintray.User == "" || conf.Bintray.Key == "" {
+		return Credentials{}, ErrNoCredentials
+	}
+	return Credentials{Subject: conf.Bintray.User, APIKey: conf.Bintray.Key}, nil
+}
+
+// ChainProvider returns the credentials of the first provider finding them.
+type ChainProvider []CredentialsProvider
+
+// Credentials asks the providers in order, skipping the ones returning ErrNoCredentials.
+func (ch ChainProvider) Credentials(ctx context.Context) (Credentials, error) {
+	for _, p := range ch {
+		c, err := p.Credentials(ctx)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+		return c, err
+	}
+	return Credentials{}, ErrNoCredentials
+}
+
+// cachedProvider resolves the credentials once.
+type cachedProvider struct {
+	provider CredentialsProvider
+
+	mu          sync.Mutex
+	resolved    bool
+	credentials Credentials
+	err         error
+}
+
+// NewCachedProvider returns a provider asking p only for the first request and
+// returning the same credentials, or ErrNoCredentials, to the following ones.
+// Other errors are not cached, so that p is asked again.
+func NewCachedProvider(p CredentialsProvider) CredentialsProvider {
+	return &cachedProvider{provider: p}
+}
+
+// Credentials returns the cached credentials, asking the wrapped provider the first time.
+func (cp *cachedProvider) Credentials(ctx context.Context) (Credentials, error) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	if cp.resolved {
+		return cp.credentials, cp.err
+	}
+	c, err := cp.provider.Credentials(ctx)
+	if err != nil && !errors.Is(err, ErrNoCredentials) {
+		return c, err
+	}
+	cp.resolved, cp.credentials, cp.err = true, c, err
+	return c, err
+}
+
+// DefaultCredentialsProvider returns a provider looking for credentials in the
+// environment, then in ~/.netrc and finally in the JFrog CLI config file.
+// The files are read once, at the first request.
+func DefaultCredentialsProvider() CredentialsProvider {
+	return ChainProvider{EnvProvider{}, NewCachedProvider(NetrcProvider{}), NewCachedProvider(ConfigFileProvider{})}
+}
@@ -0,0 +1,181 @@
+package bintray
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"testing"
+)
+
+func TestEnvProvider(t *testing.T) {
+	t.Setenv(EnvUser, "envuser")
+	t.Setenv(EnvAPIKey, "")
+	t.Setenv(envKey, "envkey")
+	c, err := EnvProvider{}.Credentials(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if c != (Credentials{Subject: "envuser", APIKey: "envkey"}) {
+		t.Errorf("unexpected credentials %#v", c)
+	}
+	t.Setenv(EnvUser, "")
+	if _, err := (EnvProvider{}).Credentials(context.Background()); err != ErrNoCredentials {
+		t.Errorf("expected ErrNoCredentials, got %#v", err)
+	}
+}
+
+func TestParseNetrc(t *testing.T) {
+	data := `
+machine github.com login gh password ghpass
+macdef init
+machine api.bintray.com login fake password fake
+
+machine api.bintray.com
+  login sub
+  password key
+default login anon password anonkey
+`
+	c, found := parseNetrc(data, "api.bintray.com")
+	if !found || c != (Credentials{Subject: "sub", APIKey: "key"}) {
+		t.Errorf("unexpected credentials %#v", c)
+	}
+	c, found = parseNetrc(data, "other.example.com")
+	if !found || c != (Credentials{Subject: "anon", APIKey: "anonkey"}) {
+		t.Errorf("expected default credentials, got %#v", c)
+	}
+	if _, found := parseNetrc("machine a login b password c", "x"); found {
+		t.Errorf("expected no credentials")
+	}
+}
+
+func TestNetrcProvider(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".netrc")
+	ioutil.WriteFile(path, []byte("machine bintray.example.com login sub password key\n"), 0600)
+	c, err := NetrcProvider{Path: path, Machine: "bintray.example.com"}.Credentials(context.Background())
+	if err != nil || c.Subject != "sub" || c.APIKey != "key" {
+		t.Errorf("unexpected credentials %#v, %v", c, err)
+	}
+	_, err = NetrcProvider{Path: filepath.Join(dir, "missing")}.Credentials(context.Background())
+	if err != ErrNoCredentials {
+		t.Errorf("expected ErrNoCredentials, got %#v", err)
+	}
+}
+
+func TestConfigFileProvider(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"jfrog-cli.conf": `{"bintray":{"user":"sub","key":"key","defPackageLicense":"Apache-2.0"},"Version":"1"}`,
+		"bintray.yaml":   "bintray:\n  user: sub\n  key: key\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		ioutil.WriteFile(path, []byte(content), 0600)
+		c, err := ConfigFileProvider{Path: path}.Credentials(context.Background())
+		if err != nil || c != (Credentials{Subject: "sub", APIKey: "key"}) {
+			t.Errorf("%s: unexpected credentials %#v, %v", name, c, err)
+		}
+	}
+	path := filepath.Join(dir, "broken.json")
+	ioutil.WriteFile(path, []byte("{"), 0600)
+	if _, err := (ConfigFileProvider{Path: path}).Credentials(context.Background()); err == nil || err == ErrNoCredentials {
+		t.Errorf("expected parse error, got %#v", err)
+	}
+}
+
+type failingProvider struct{}
+
+func (failingProvider) Credentials(ctx context.Context) (Credentials, error) {
+	return Credentials{}, errors.New("vault unreachable")
+}
+
+func TestChainProvider(t *testing.T) {
+	chain := ChainProvider{StaticProvider{}, StaticProvider{Subject: "sub", APIKey: "key"}, failingProvider{}}
+	c, err := chain.Credentials(context.Background())
+	if err != nil || c.Subject != "sub" {
+		t.Errorf("unexpected credentials %#v, %v", c, err)
+	}
+	if _, err := (ChainProvider{StaticProvider{}}).Credentials(context.Background()); err != ErrNoCredentials {
+		t.Errorf("expected ErrNoCredentials, got %#v", err)
+	}
+	if _, err := (ChainProvider{failingProvider{}, StaticProvider{Subject: "sub"}}).Credentials(context.Background()); err == nil {
+		t.Errorf("expected provider error")
+	}
+}
+
+func TestClientCredentialsProvider(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		user, key, _ := r.BasicAuth()
+		fmt.Fprint(w, user+":"+key)
+	})
+	client.CredentialsProvider = StaticProvider{Subject: "provided", APIKey: "secret"}
+	req, _ := client.newRequestWithBody(context.Background(), "GET", "/", "")
+	response, _ := client.execute(req)
+	testResponse(t, response, "provided:secret", 200)
+
+	client.CredentialsProvider = ChainProvider{}
+	client.subject, client.apikey = "", ""
+	req, _ = client.newRequestWithBody(context.Background(), "GET", "/", "")
+	if req.Header.Get("Authorization") != "" {
+		t.Errorf("request without credentials should not be authenticated")
+	}
+
+	client.CredentialsProvider = failingProvider{}
+	if _, err := client.newRequestWithBody(context.Background(), "GET", "/", ""); err == nil {
+		t.Errorf("expected provider error")
+	}
+}
+
+func TestClientCredentialsProvider_noCredentials(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		user, key, _ := r.BasicAuth()
+		fmt.Fprint(w, user+":"+key)
+	})
+	client.CredentialsProvider = ChainProvider{StaticProvider{}}
+	req, _ := client.newRequestWithBody(context.Background(), "GET", "", "")
+	response, _ := client.execute(req)
+	testResponse(t, response, "sub:api", 200)
+}
+
+// countingProvider counts the calls, returning err if not nil.
+type countingProvider struct {
+	calls int
+	err   error
+}
+
+func (p *countingProvider) Credentials(ctx context.Context) (Credentials, error) {
+	p.calls++
+	if p.err != nil {
+		return Credentials{}, p.err
+	}
+	return Credentials{Subject: "sub", APIKey: "key"}, nil
+}
+
+func TestCachedProvider(t *testing.T) {
+	for _, err := range []error{nil, ErrNoCredentials} {
+		p := &countingProvider{err: err}
+		cached := NewCachedProvider(p)
+		for i := 0; i < 3; i++ {
+			if _, cerr := cached.Credentials(context.Background()); cerr != err {
+				t.Errorf("unexpected error %v, want %v", cerr, err)
+			}
+		}
+		if p.calls != 1 {
+			t.Errorf("provider called %d times, want once", p.calls)
+		}
+	}
+	p := &countingProvider{err: errors.New("permission denied")}
+	cached := NewCachedProvider(p)
+	cached.Credentials(context.Background())
+	cached.Credentials(context.Background())
+	if p.calls != 2 {
+		t.Errorf("errors should not be cached, provider called %d times", p.calls)
+	}
+}
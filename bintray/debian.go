@@ -0,0 +1,278 @@
+package bintray
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/ulikunitz/xz"
+)
+
+const (
+	arMagic        = "!<arch>\n"
+	arHeaderLength = 60
+
+	defaultDebianComponent = "main"
+)
+
+// DebianControl is the metadata of a Debian binary package, read from its control file.
+type DebianControl struct {
+	Package      string
+	Version      string
+	Architecture string
+	Source       string
+	Maintainer   string
+	Section      string
+	// Description is the first line of the package description.
+	Description string
+	// Fields are all the fields of the control file, by name.
+	Fields map[string]string
+}
+
+// ReadDebianControl reads the control file of a .deb package: the ar archive is
+// read up to the control tarball, which can be uncompressed or compressed with
+// gzip or xz. Control tarballs compressed with zstd, as built by recent Ubuntu
+// releases, are not supported.
+func ReadDebianControl(deb io.Reader) (*DebianControl, error) {
+	magic := make([]byte, len(arMagic))
+	if _, err := io.ReadFull(deb, magic); err != nil || string(magic) != arMagic {
+		return nil, errors.New("ReadDebianControl: not a Debian package")
+	}
+	header := make([]byte, arHeaderLength)
+	for {
+		if _, err := io.ReadFull(deb, header); err != nil {
+			if err == io.EOF {
+				return nil, errors.New("ReadDebianControl: control archive not found")
+			}
+			return nil, err
+		}
+		// GNU ar terminates the names with a slash
+		name := strings.TrimSuffix(strings.TrimSpace(string(header[0:16])), "/")
+		size, err := strconv.ParseInt(strings.TrimSpace(string(header[48:58])), 10, 64)
+		if err != nil || string(header[58:60]) != "`\n" {
+			return nil, errors.New("ReadDebianControl: invalid ar header")
+		}
+		if strings.HasPrefix(name, "control.tar") {
+			return readControlArchive(io.LimitReader(deb, size), name)
+		}
+		// entries are aligned to an even offset
+		if _, err := io.CopyN(ioutil.Discard, deb, size+size%2); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func readControlArchive(r io.Reader, name string) (*DebianControl, error) {
+	switch path.Ext(name) {
+	case ".tar":
+	case ".gz":
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		r = gz
+	case ".xz":
+		xzr, err := xz.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		r = xzr
+	case ".zst":
+		return nil, errors.New("ReadDebianControl: unsupported control archive compression: zst")
+	default:
+		return nil, fmt.Errorf("ReadDebianControl: unsupported control archive %s", name)
+	}
+	tr := tar.NewReader(r)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return nil, errors.New("ReadDebianControl: control file not found")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if path.Clean(strings.TrimPrefix(h.Name, "./")) == "control" {
+			data, err := ioutil.ReadAll(tr)
+			if err != nil {
+				return nil, err
+			}
+			return parseDebianControl(data)
+		}
+	}
+}
+
+// parseDebianControl parses the fields of a control file, joining the continuation lines.
+func parseDebianControl(data []byte) (*DebianControl, error) {
+	fields := map[string]string{}
+	last := ""
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if line[0] == ' ' || line[0] == '\t' {
+			if last == "" {
+				return nil, fmt.Errorf("ReadDebianControl: unexpected continuation line %q", line)
+			}
+			fields[last] += "\n" + strings.TrimSpace(line)
+			continue
+		}
+		i := strings.Index(line, ":")
+		if i <= 0 {
+			return nil, fmt.Errorf("ReadDebianControl: invalid line %q", line)
+		}
+		last = line[:i]
+		fields[last] = strings.TrimSpace(line[i+1:])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	c := &DebianControl{
+		Package:      fields["Package"],
+		Version:      fields["Version"],
+		Architecture: fields["Architecture"],
+		Source:       fields["Source"],
+		Maintainer:   fields["Maintainer"],
+		Section:      fields["Section"],
+		Description:  strings.SplitN(fields["Description"], "\n", 2)[0],
+		Fields:       fields,
+	}
+	if c.Package == "" || c.Version == "" || c.Architecture == "" {
+		return nil, errors.New("ReadDebianControl: package, version and architecture shouldn't be empty")
+	}
+	return c, nil
+}
+
+// FileName returns the conventional file name of the package, ie `hello_2.10-1_amd64.deb`.
+// The epoch of the version is not included.
+func (c *DebianControl) FileName() string {
+	return c.Package + "_" + c.versionWithoutEpoch() + "_" + c.Architecture + ".deb"
+}
+
+// versionWithoutEpoch returns the version without the `epoch:` prefix, ie `2.10-1` for `1:2.10-1`.
+func (c *DebianControl) versionWithoutEpoch() string {
+	if i := strings.Index(c.Version, ":"); i >= 0 {
+		return c.Version[i+1:]
+	}
+	return c.Version
+}
+
+// PoolPath returns the path of the package in the pool of a Debian repository,
+// ie `pool/main/h/hello/hello_2.10-1_amd64.deb` or `pool/main/libf/libfoo/libfoo1_1.0_amd64.deb`.
+func (c *DebianControl) PoolPath(component string) string {
+	if component == "" {
+		component = defaultDebianComponent
+	}
+	// the source package name, without the version in parenthesis
+	source := c.Package
+	if fields := strings.Fields(c.Source); len(fields) > 0 {
+		source = fields[0]
+	}
+	if source == "" {
+		return "pool/" + component + "/" + c.FileName()
+	}
+	prefix := source[:1]
+	if strings.HasPrefix(source, "lib") && len(source) > 3 {
+		prefix = source[:4]
+	}
+	return "pool/" + component + "/" + prefix + "/" + source + "/" + c.FileName()
+}
+
+// DebianUploadRequest describes the upload of a .deb package.
+type DebianUploadRequest struct {
+	Subject    string
+	Repository string
+
+	// Package and Version are the Bintray package and version.
+	// If empty, the package name and version of the control file are used,
+	// the version without the epoch as in the file name.
+	Package string
+	Version string
+
+	// Body is the content of the .deb: it is read to get the control file, then
+	// rewound and uploaded.
+	Body io.ReadSeeker
+
+	// UploadOptions are the options of the upload. DebianDistribution, ie `stretch`
+	// or `focal`, is mandatory, DebianComponent defaults to `main` and
+	// DebianArchitecture to the architecture of the control file.
+	UploadOptions
+
+	// CalcMetadata schedules the calculation of the repository indexes after the upload.
+	CalcMetadata bool
+
+	// Progress is the UploadRequest progress reporter.
+	Progress ProgressReporter
+}
+
+// DebianUploadResult reports the outcome of a Debian upload.
+type DebianUploadResult struct {
+	Control *DebianControl
+	Upload  *UploadResult
+}
+
+// UploadDebian uploads a .deb package to its pool path, sending the Debian metadata.
+func (c *Client) UploadDebian(ctx context.Context, r *DebianUploadRequest) (*DebianUploadResult, error) {
+	if r == nil || r.Subject == "" || r.Repository == "" || r.Body == nil || len(r.DebianDistribution) == 0 {
+		return nil, errors.New("UploadDebian: subject, repository, body and distributions shouldn't be empty")
+	}
+	start, err := r.Body.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+	control, err := ReadDebianControl(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	end, err := r.Body.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.Body.Seek(start, io.SeekStart); err != nil {
+		return nil, err
+	}
+	opts := r.UploadOptions
+	if len(opts.DebianComponent) == 0 {
+		opts.DebianComponent = []string{defaultDebianComponent}
+	}
+	if len(opts.DebianArchitecture) == 0 {
+		opts.DebianArchitecture = []string{control.Architecture}
+	}
+	pkg, version := r.Package, r.Version
+	if pkg == "" {
+		pkg = control.Package
+	}
+	if version == "" {
+		version = control.versionWithoutEpoch()
+	}
+	result := &DebianUploadResult{Control: control}
+	result.Upload, err = c.Upload(ctx, &UploadRequest{
+		Subject:    r.Subject,
+		Repository: r.Repository,
+		Package:    pkg,
+		Version:    version,
+		Path:       control.PoolPath(opts.DebianComponent[0]),
+		Body:       r.Body,
+		Length:     end - start,
+		Options:    opts,
+		Progress:   r.Progress,
+	})
+	if err != nil {
+		return result, err
+	}
+	if r.CalcMetadata {
+		err = c.CalcMetadataContext(ctx, r.Subject, r.Repository, "")
+	}
+	return result, err
+}
@@ -0,0 +1,163 @@
+package bintray
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/ulikunitz/xz"
+)
+
+const debianControl = `Package: libhello1
+Source: hello (2.10-1)
+Version: 1:2.10-1
+Architecture: amd64
+Maintainer: Someone <someone@example.com>
+Section: libs
+Description: example package
+ The package used in tests.
+ .
+ It does nothing.
+`
+
+// buildDeb returns a minimal .deb with the given control file, compressing the
+// control archive as the extension says.
+func buildDeb(t *testing.T, control, ext string) []byte {
+	var tarball bytes.Buffer
+	tw := tar.NewWriter(&tarball)
+	tw.WriteHeader(&tar.Header{Name: "./", Typeflag: tar.TypeDir, Mode: 0755})
+	tw.WriteHeader(&tar.Header{Name: "./control", Mode: 0644, Size: int64(len(control))})
+	io.WriteString(tw, control)
+	tw.Close()
+
+	var compressed bytes.Buffer
+	switch ext {
+	case ".gz":
+		gz := gzip.NewWriter(&compressed)
+		gz.Write(tarball.Bytes())
+		gz.Close()
+	case ".xz":
+		xzw, err := xz.NewWriter(&compressed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		xzw.Write(tarball.Bytes())
+		xzw.Close()
+	default:
+		compressed = tarball
+	}
+
+	var deb bytes.Buffer
+	deb.WriteString(arMagic)
+	for _, entry := range []struct {
+		name string
+		data []byte
+	}{
+		{"debian-binary", []byte("2.0\n")},
+		{"control.tar" + ext, compressed.Bytes()},
+		{"data.tar.gz", []byte("data")},
+	} {
+		fmt.Fprintf(&deb, "%-16s%-12d%-6d%-6d%-8s%-10d`\n", entry.name+"/", 0, 0, 0, "100644", len(entry.data))
+		deb.Write(entry.data)
+		if len(entry.data)%2 == 1 {
+			deb.WriteByte('\n')
+		}
+	}
+	return deb.Bytes()
+}
+
+func TestReadDebianControl(t *testing.T) {
+	for _, ext := range []string{"", ".gz", ".xz"} {
+		control, err := ReadDebianControl(bytes.NewReader(buildDeb(t, debianControl, ext)))
+		if err != nil {
+			t.Errorf("control.tar%s: unexpected error thrown %s", ext, err)
+			continue
+		}
+		if control.Package != "libhello1" || control.Version != "1:2.10-1" || control.Architecture != "amd64" {
+			t.Errorf("control.tar%s: unexpected control %#v", ext, control)
+		}
+		if control.Description != "example package" {
+			t.Errorf("Description = %q", control.Description)
+		}
+		if d := control.Fields["Description"]; d != "example package\nThe package used in tests.\n.\nIt does nothing." {
+			t.Errorf("Description field = %q", d)
+		}
+		if p := control.PoolPath(""); p != "pool/main/h/hello/libhello1_2.10-1_amd64.deb" {
+			t.Errorf("PoolPath = %q", p)
+		}
+	}
+}
+
+func TestReadDebianControl_invalid(t *testing.T) {
+	if _, err := ReadDebianControl(bytes.NewReader([]byte("not a deb"))); err == nil {
+		t.Errorf("expected error reading an invalid package")
+	}
+	if _, err := ReadDebianControl(bytes.NewReader(buildDeb(t, "Package: hello\n", ".gz"))); err == nil {
+		t.Errorf("expected error for control without version")
+	}
+	_, err := ReadDebianControl(bytes.NewReader(buildDeb(t, debianControl, ".zst")))
+	if err == nil || err.Error() != "ReadDebianControl: unsupported control archive compression: zst" {
+		t.Errorf("unexpected error for zstd control archive: %v", err)
+	}
+}
+
+func TestDebianControl_PoolPath(t *testing.T) {
+	tests := []struct {
+		control  DebianControl
+		expected string
+	}{
+		{DebianControl{Package: "hello", Version: "2.10-1", Architecture: "all"}, "pool/contrib/h/hello/hello_2.10-1_all.deb"},
+		{DebianControl{Package: "libfoo1", Version: "1.0", Architecture: "arm64"}, "pool/contrib/libf/libfoo1/libfoo1_1.0_arm64.deb"},
+	}
+	for _, tt := range tests {
+		if p := tt.control.PoolPath("contrib"); p != tt.expected {
+			t.Errorf("PoolPath = %q, want %q", p, tt.expected)
+		}
+	}
+}
+
+func TestUploadDebian(t *testing.T) {
+	setup()
+	defer teardown()
+	deb := buildDeb(t, debianControl, ".gz")
+	mux.HandleFunc("/content/subject/debian/libhello1/2.10-1/pool/main/h/hello/libhello1_2.10-1_amd64.deb", func(w http.ResponseWriter, r *http.Request) {
+		testHeader(t, r, "X-Bintray-Debian-Distribution", "stretch,buster")
+		testHeader(t, r, "X-Bintray-Debian-Component", "main")
+		testHeader(t, r, "X-Bintray-Debian-Architecture", "amd64")
+		body, _ := ioutil.ReadAll(r.Body)
+		if !bytes.Equal(body, deb) {
+			t.Errorf("uploaded %d bytes, want the whole package (%d bytes)", len(body), len(deb))
+		}
+		w.WriteHeader(201)
+	})
+	calculated := false
+	mux.HandleFunc("/calc_metadata/subject/debian", func(w http.ResponseWriter, r *http.Request) {
+		if m := "POST"; m != r.Method {
+			t.Errorf("Request method = %v, want %v", r.Method, m)
+		}
+		calculated = true
+		w.WriteHeader(202)
+	})
+	result, err := client.UploadDebian(context.Background(), &DebianUploadRequest{
+		Subject:       "subject",
+		Repository:    "debian",
+		Body:          bytes.NewReader(deb),
+		UploadOptions: UploadOptions{DebianDistribution: []string{"stretch", "buster"}},
+		CalcMetadata:  true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if result.Control.Package != "libhello1" || result.Upload.Path != "pool/main/h/hello/libhello1_2.10-1_amd64.deb" {
+		t.Errorf("unexpected result %#v", result)
+	}
+	if !calculated {
+		t.Errorf("metadata calculation not requested")
+	}
+}
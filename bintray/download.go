@@ -0,0 +1,188 @@
+package bintray
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// DownloadOptions specifies the optional parameters to DownloadToPath and DownloadFileWithOptions.
+type DownloadOptions struct {
+	// Sha1 is the expected checksum of the file, as found in FileData.
+	// If set, the downloaded file is verified and removed if it doesn't match.
+	// Downloading to an io.Writer the content is verified once written.
+	Sha1 string
+
+	// NoResume disables the resume of a partially downloaded file:
+	// an existing file at the destination path is overwritten.
+	// It has no effect downloading to an io.Writer.
+	NoResume bool
+
+	// Progress, if not nil, is notified while the file is received.
+	Progress ProgressReporter
+}
+
+// DownloadFile downloads a file from the downloads host writing its content to w.
+// The request is authenticated, so that files in private repositories can be downloaded.
+// GET https://dl.bintray.com/:subject/:repo/:file_path
+func (c *Client) DownloadFile(subject, repository, filePath string, w io.Writer) error {
+	return c.DownloadFileContext(context.Background(), subject, repository, filePath, w)
+}
+
+// DownloadFileContext is like DownloadFile but uses the given context for the request.
+func (c *Client) DownloadFileContext(ctx context.Context, subject, repository, filePath string, w io.Writer) error {
+	return c.DownloadFileWithOptionsContext(ctx, subject, repository, filePath, w, nil)
+}
+
+// DownloadFileWithOptions is like DownloadFile, optionally verifying the SHA1 of
+// the content and reporting the progress.
+func (c *Client) DownloadFileWithOptions(subject, repository, filePath string, w io.Writer, opts *DownloadOptions) error {
+	return c.DownloadFileWithOptionsContext(context.Background(), subject, repository, filePath, w, opts)
+}
+
+// DownloadFileWithOptionsContext is like DownloadFileWithOptions but uses the given context for the request.
+func (c *Client) DownloadFileWithOptionsContext(ctx context.Context, subject, repository, filePath string, w io.Writer, opts *DownloadOptions) error {
+	if subject == "" || repository == "" || filePath == "" {
+		return errors.New("DownloadFile: subject, repository and file path shouldn't be empty")
+	}
+	if opts == nil {
+		opts = &DownloadOptions{}
+	}
+	req, err := c.newDownloadRequest(ctx, subject, repository, filePath)
+	if err != nil {
+		return err
+	}
+	resp, err := c.execute(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	h := sha1.New()
+	var total int64
+	if resp.ContentLength > 0 {
+		total = resp.ContentLength
+	}
+	body := io.TeeReader(newProgressReader(resp.Body, 0, total, opts.Progress), h)
+	if _, err := io.Copy(w, body); err != nil {
+		return err
+	}
+	if actual := hex.EncodeToString(h.Sum(nil)); opts.Sha1 != "" && !strings.EqualFold(actual, opts.Sha1) {
+		return &ChecksumError{Path: filePath, Expected: opts.Sha1, Actual: actual}
+	}
+	return nil
+}
+
+// DownloadToPath downloads a file from the downloads host saving it to destPath.
+// If destPath already exists the download is resumed from its current size,
+// unless opts.NoResume is set.
+// A nil opts downloads the file without checksum verification.
+func (c *Client) DownloadToPath(subject, repository, filePath, destPath string, opts *DownloadOptions) error {
+	return c.DownloadToPathContext(context.Background(), subject, repository, filePath, destPath, opts)
+}
+
+// DownloadToPathContext is like DownloadToPath but uses the given context for the request.
+func (c *Client) DownloadToPathContext(ctx context.Context, subject, repository, filePath, destPath string, opts *DownloadOptions) error {
+	if subject == "" || repository == "" || filePath == "" || destPath == "" {
+		return errors.New("DownloadToPath: subject, repository, file path and destination shouldn't be empty")
+	}
+	if opts == nil {
+		opts = &DownloadOptions{}
+	}
+	var offset int64
+	if !opts.NoResume {
+		if fi, err := os.Stat(destPath); err == nil && fi.Mode().IsRegular() {
+			offset = fi.Size()
+		}
+	}
+	req, err := c.newDownloadRequest(ctx, subject, repository, filePath)
+	if err != nil {
+		return err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", "bytes="+strconv.FormatInt(offset, 10)+"-")
+	}
+	resp, err := c.execute(req)
+	var errorResponse *ErrorResponse
+	if offset > 0 && errors.As(err, &errorResponse) && errorResponse.Response.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+		// the file is already complete
+		resp.Body.Close()
+		return verifyDownload(destPath, opts.Sha1)
+	}
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if offset > 0 && resp.StatusCode == http.StatusPartialContent {
+		flags = os.O_WRONLY | os.O_APPEND
+	} else {
+		offset = 0
+	}
+	file, err := os.OpenFile(destPath, flags, 0644)
+	if err != nil {
+		return err
+	}
+	var total int64
+	if resp.ContentLength > 0 {
+		total = offset + resp.ContentLength
+	}
+	_, err = io.Copy(file, newProgressReader(resp.Body, offset, total, opts.Progress))
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		// keep the partial file to resume the download
+		return err
+	}
+	return verifyDownload(destPath, opts.Sha1)
+}
+
+// verifyDownload checks the file checksum, removing the file if it doesn't match.
+func verifyDownload(path, expectedSha1 string) error {
+	if expectedSha1 == "" {
+		return nil
+	}
+	actual, err := fileSha1(path)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(actual, expectedSha1) {
+		os.Remove(path)
+		return &ChecksumError{Path: path, Expected: expectedSha1, Actual: actual}
+	}
+	return nil
+}
+
+func fileSha1(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	h := sha1.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// newDownloadRequest creates a GET request for a file in the downloads host.
+func (c *Client) newDownloadRequest(ctx context.Context, subject, repository, filePath string) (*http.Request, error) {
+	base, err := url.Parse(c.downloadsHost)
+	if err != nil {
+		return nil, err
+	}
+	rel, err := url.Parse(subject + "/" + repository + "/" + strings.TrimPrefix(filePath, "/"))
+	if err != nil {
+		return nil, err
+	}
+	return c.newRequest(ctx, "GET", base.ResolveReference(rel), nil, 0)
+}
@@ -0,0 +1,132 @@
+package bintray
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const downloadContent = "Hello 01!\nthis is the file content\n"
+
+func setupDownload(t *testing.T) string {
+	setup()
+	client.downloadsHost = server.URL + "/dl/"
+	mux.HandleFunc("/dl/subject/repository/a/file.txt", func(w http.ResponseWriter, r *http.Request) {
+		testHeader(t, r, "Authorization", "Basic c3ViOmFwaQ==")
+		http.ServeContent(w, r, "file.txt", time.Time{}, strings.NewReader(downloadContent))
+	})
+	dir, err := ioutil.TempDir("", "bintray")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestDownloadFile(t *testing.T) {
+	setupDownload(t)
+	defer teardown()
+	var buf bytes.Buffer
+	err := client.DownloadFile("subject", "repository", "a/file.txt", &buf)
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if buf.String() != downloadContent {
+		t.Errorf("downloaded %q, want %q", buf.String(), downloadContent)
+	}
+}
+
+func TestDownloadFile_notFound(t *testing.T) {
+	setupDownload(t)
+	defer teardown()
+	var buf bytes.Buffer
+	err := client.DownloadFile("subject", "repository", "missing.txt", &buf)
+	if !IsNotFound(err) {
+		t.Errorf("expected not found error, got %#v", err)
+	}
+}
+
+func TestDownloadToPath(t *testing.T) {
+	dir := setupDownload(t)
+	defer teardown()
+	defer os.RemoveAll(dir)
+	sha := sha1Of(downloadContent)
+	dest := filepath.Join(dir, "file.txt")
+	err := client.DownloadToPath("subject", "repository", "a/file.txt", dest, &DownloadOptions{Sha1: sha})
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	testFileContent(t, dest, downloadContent)
+}
+
+func TestDownloadToPath_resume(t *testing.T) {
+	dir := setupDownload(t)
+	defer teardown()
+	defer os.RemoveAll(dir)
+	dest := filepath.Join(dir, "file.txt")
+	ioutil.WriteFile(dest, []byte(downloadContent[:10]), 0644)
+	sha := sha1Of(downloadContent)
+	err := client.DownloadToPath("subject", "repository", "a/file.txt", dest, &DownloadOptions{Sha1: sha})
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	testFileContent(t, dest, downloadContent)
+
+	// already complete
+	err = client.DownloadToPath("subject", "repository", "a/file.txt", dest, &DownloadOptions{Sha1: sha})
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	testFileContent(t, dest, downloadContent)
+}
+
+func TestDownloadToPath_checksumMismatch(t *testing.T) {
+	dir := setupDownload(t)
+	defer teardown()
+	defer os.RemoveAll(dir)
+	dest := filepath.Join(dir, "file.txt")
+	err := client.DownloadToPath("subject", "repository", "a/file.txt", dest, &DownloadOptions{Sha1: sha1Of("other content")})
+	var checksumError *ChecksumError
+	if !errors.As(err, &checksumError) {
+		t.Fatalf("expected ChecksumError, got %#v", err)
+	}
+	if _, err := os.Stat(dest); !os.IsNotExist(err) {
+		t.Errorf("file not matching the checksum should be removed")
+	}
+}
+
+func TestDownloadFileWithOptions_checksumMismatch(t *testing.T) {
+	setupDownload(t)
+	defer teardown()
+	var buf bytes.Buffer
+	err := client.DownloadFileWithOptions("subject", "repository", "a/file.txt", &buf, &DownloadOptions{Sha1: sha1Of("other content")})
+	var checksumError *ChecksumError
+	if !errors.As(err, &checksumError) {
+		t.Fatalf("expected ChecksumError, got %#v", err)
+	}
+	if err := client.DownloadFileWithOptions("subject", "repository", "a/file.txt", &buf, &DownloadOptions{Sha1: sha1Of(downloadContent)}); err != nil {
+		t.Errorf("unexpected error thrown %s", err)
+	}
+}
+
+func sha1Of(content string) string {
+	sum := sha1.Sum([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func testFileContent(t *testing.T, path, expected string) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("error reading %s: %v", path, err)
+	}
+	if string(data) != expected {
+		t.Errorf("file content %q, want %q", data, expected)
+	}
+}
@@ -1,15 +1,81 @@
 package bintray
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 )
 
+var (
+	// ErrNotFound is matched by an ErrorResponse with status 404.
+	ErrNotFound = errors.New("bintray: not found")
+	// ErrConflict is matched by an ErrorResponse with status 409,
+	// returned for example uploading a file already existing.
+	ErrConflict = errors.New("bintray: conflict")
+	// ErrUnauthorized is matched by an ErrorResponse with status 401.
+	ErrUnauthorized = errors.New("bintray: unauthorized")
+	// ErrRateLimited is matched by an ErrorResponse with status 429.
+	ErrRateLimited = errors.New("bintray: rate limited")
+	// ErrNoMatchingVersion is returned resolving a Maven version not matching any published version.
+	ErrNoMatchingVersion = errors.New("bintray: no matching version")
+)
+
 // An ErrorResponse reports one or more errors caused by an API request.
 type ErrorResponse struct {
 	Response *http.Response // HTTP response that caused this error
+	Message  string         `json:"message"` // error message returned by Bintray
 }
 
 func (r *ErrorResponse) Error() string {
-	return fmt.Sprintf("%v %v: %d", r.Response.Request.Method, r.Response.Request.URL, r.Response.StatusCode)
+	if r.Message == "" {
+		return fmt.Sprintf("%v %v: %d", r.Response.Request.Method, r.Response.Request.URL, r.Response.StatusCode)
+	}
+	return fmt.Sprintf("%v %v: %d %v", r.Response.Request.Method, r.Response.Request.URL, r.Response.StatusCode, r.Message)
+}
+
+// Is reports if the status code of the response matches the given sentinel error,
+// so that errors.Is(err, ErrNotFound) works on ErrorResponse values.
+func (r *ErrorResponse) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return r.Response.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return r.Response.StatusCode == http.StatusConflict
+	case ErrUnauthorized:
+		return r.Response.StatusCode == http.StatusUnauthorized
+	case ErrRateLimited:
+		return r.Response.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// IsNotFound returns true if err is an ErrorResponse with status 404.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsConflict returns true if err is an ErrorResponse with status 409.
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+// IsUnauthorized returns true if err is an ErrorResponse with status 401.
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+// IsRateLimited returns true if err is an ErrorResponse with status 429.
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
+
+// A ChecksumError reports a downloaded file not matching the expected checksum.
+type ChecksumError struct {
+	Path     string // path of the file
+	Expected string // expected checksum
+	Actual   string // checksum of the downloaded content
+}
+
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("%s: checksum mismatch, expected %s got %s", e.Path, e.Expected, e.Actual)
 }
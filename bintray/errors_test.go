@@ -0,0 +1,105 @@
+package bintray
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCheckResponse_message(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"message":"Unable to upload files: An artifact with the path 'a/b.txt' already exists"}`))
+	})
+	err := client.UploadFile("subject", "repository", "pkg", "1.2", "", "", "testdata/01.txt", "", false)
+	var errorResponse *ErrorResponse
+	if !errors.As(err, &errorResponse) {
+		t.Fatalf("expected ErrorResponse, got %#v", err)
+	}
+	expected := "Unable to upload files: An artifact with the path 'a/b.txt' already exists"
+	if errorResponse.Message != expected {
+		t.Errorf("ErrorResponse.Message = %q, want %q", errorResponse.Message, expected)
+	}
+	if !strings.Contains(err.Error(), expected) {
+		t.Errorf("error string %q should contain the message", err.Error())
+	}
+	if !IsConflict(err) {
+		t.Errorf("expected IsConflict true")
+	}
+	if IsNotFound(err) {
+		t.Errorf("expected IsNotFound false")
+	}
+}
+
+func TestCheckResponse_notJSON(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "<html>bad gateway</html>", http.StatusBadGateway)
+	})
+	req, _ := client.newRequestWithBody(context.Background(), "GET", "/", "")
+	response, err := client.execute(req)
+	var errorResponse *ErrorResponse
+	if !errors.As(err, &errorResponse) {
+		t.Fatalf("expected ErrorResponse, got %#v", err)
+	}
+	if errorResponse.Message != "" {
+		t.Errorf("unexpected message %q", errorResponse.Message)
+	}
+	// body is still readable by the caller
+	testResponse(t, response, "<html>bad gateway</html>", http.StatusBadGateway)
+}
+
+func TestCheckResponse_largeBody(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write(bytes.Repeat([]byte("x"), maxErrorBodySize+1024))
+	})
+	req, _ := client.newRequestWithBody(context.Background(), "GET", "/", "")
+	response, err := client.execute(req)
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	body, _ := ioutil.ReadAll(response.Body)
+	if len(body) != maxErrorBodySize {
+		t.Errorf("read %d bytes of the error body, want %d", len(body), maxErrorBodySize)
+	}
+}
+
+func TestErrorHelpers(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		is         func(error) bool
+		sentinel   error
+	}{
+		{http.StatusNotFound, IsNotFound, ErrNotFound},
+		{http.StatusConflict, IsConflict, ErrConflict},
+		{http.StatusUnauthorized, IsUnauthorized, ErrUnauthorized},
+		{http.StatusTooManyRequests, IsRateLimited, ErrRateLimited},
+	}
+	for _, tt := range tests {
+		err := error(&ErrorResponse{Response: &http.Response{StatusCode: tt.statusCode}})
+		if !tt.is(err) {
+			t.Errorf("helper for %d returned false", tt.statusCode)
+		}
+		if !errors.Is(err, tt.sentinel) {
+			t.Errorf("errors.Is(%d, %v) returned false", tt.statusCode, tt.sentinel)
+		}
+		other := error(&ErrorResponse{Response: &http.Response{StatusCode: http.StatusInternalServerError}})
+		if tt.is(other) {
+			t.Errorf("helper for %d returned true on 500", tt.statusCode)
+		}
+	}
+	if IsNotFound(nil) || IsNotFound(errors.New("404")) {
+		t.Errorf("IsNotFound should be false for non ErrorResponse errors")
+	}
+}
@@ -0,0 +1,136 @@
+package bintray
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+// SignOptions specifies the parameters of a Bintray side GPG signing.
+type SignOptions struct {
+	// Passphrase of the GPG key stored in Bintray, if protected.
+	Passphrase string
+	// KeyOwner is the subject owning the key (ie an organization); if empty
+	// the key of the authenticated user or of the repository is used.
+	KeyOwner string
+	// PassphraseInHeader sends the passphrase in the X-GPG-PASSPHRASE header
+	// instead of the request body.
+	PassphraseInHeader bool
+}
+
+type signBody struct {
+	KeyOwner   string `json:"subject,omitempty"`
+	Passphrase string `json:"passphrase,omitempty"`
+}
+
+// SignVersion signs with GPG all the files of a version.
+// A nil opts signs with the default key without passphrase.
+// POST /gpg/:subject/:repo/:package/versions/:version
+func (c *Client) SignVersion(subject, repository, pkg, version string, opts *SignOptions) error {
+	return c.SignVersionContext(context.Background(), subject, repository, pkg, version, opts)
+}
+
+// SignVersionContext is like SignVersion but uses the given context for the request.
+func (c *Client) SignVersionContext(ctx context.Context, subject, repository, pkg, version string, opts *SignOptions) error {
+	if subject == "" || repository == "" || pkg == "" || version == "" {
+		return errors.New("SignVersion: subject, repository, package name and version shouldn't be empty")
+	}
+	return c.sign(ctx, "gpg/"+subject+"/"+repository+"/"+pkg+"/versions/"+version, opts)
+}
+
+// SignFile signs with GPG a single file, creating its `.asc` signature.
+// A nil opts signs with the default key without passphrase.
+// POST /gpg/:subject/:repo/:file_path
+func (c *Client) SignFile(subject, repository, filePath string, opts *SignOptions) error {
+	return c.SignFileContext(context.Background(), subject, repository, filePath, opts)
+}
+
+// SignFileContext is like SignFile but uses the given context for the request.
+func (c *Client) SignFileContext(ctx context.Context, subject, repository, filePath string, opts *SignOptions) error {
+	remotePath, err := cleanRemotePath(filePath)
+	if subject == "" || repository == "" || err != nil {
+		return errors.New("SignFile: subject, repository and a valid file path shouldn't be empty")
+	}
+	return c.sign(ctx, "gpg/"+subject+"/"+repository+"/"+remotePath, opts)
+}
+
+func (c *Client) sign(ctx context.Context, url string, opts *SignOptions) error {
+	if opts == nil {
+		opts = &SignOptions{}
+	}
+	body := &signBody{KeyOwner: opts.KeyOwner}
+	if !opts.PassphraseInHeader {
+		body.Passphrase = opts.Passphrase
+	}
+	var requestData string
+	if body.KeyOwner != "" || body.Passphrase != "" {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		requestData = string(data)
+	}
+	req, err := c.newRequestWithBody(ctx, "POST", url, requestData)
+	if err != nil {
+		return err
+	}
+	if requestData != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if opts.PassphraseInHeader && opts.Passphrase != "" {
+		req.Header.Set("X-GPG-PASSPHRASE", opts.Passphrase)
+	}
+	resp, err := c.execute(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// GetSubjectPublicKey returns the ASCII armored GPG public key of a user or organization.
+// GET /users/:user/keys/gpg/public.key
+// GET /orgs/:org/keys/gpg/public.key
+func (c *Client) GetSubjectPublicKey(subject string) (string, error) {
+	return c.GetSubjectPublicKeyContext(context.Background(), subject)
+}
+
+// GetSubjectPublicKeyContext is like GetSubjectPublicKey but uses the given context for the request.
+func (c *Client) GetSubjectPublicKeyContext(ctx context.Context, subject string) (string, error) {
+	if subject == "" {
+		return "", errors.New("GetSubjectPublicKey: subject shouldn't be empty")
+	}
+	key, err := c.getText(ctx, "users/"+subject+"/keys/gpg/public.key")
+	if IsNotFound(err) {
+		// the subject is an organization
+		return c.getText(ctx, "orgs/"+subject+"/keys/gpg/public.key")
+	}
+	return key, err
+}
+
+// GetRepoPublicKey returns the ASCII armored GPG public key of a repository.
+// GET /repos/:subject/:repo/keys/gpg/public.key
+func (c *Client) GetRepoPublicKey(subject, repository string) (string, error) {
+	return c.GetRepoPublicKeyContext(context.Background(), subject, repository)
+}
+
+// GetRepoPublicKeyContext is like GetRepoPublicKey but uses the given context for the request.
+func (c *Client) GetRepoPublicKeyContext(ctx context.Context, subject, repository string) (string, error) {
+	if subject == "" || repository == "" {
+		return "", errors.New("GetRepoPublicKey: subject and repository shouldn't be empty")
+	}
+	return c.getText(ctx, "repos/"+subject+"/"+repository+"/keys/gpg/public.key")
+}
+
+// getText returns the body of a GET request.
+func (c *Client) getText(ctx context.Context, url string) (string, error) {
+	req, err := c.newRequestWithReader(ctx, "GET", url, nil, 0)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.execute(req)
+	if err != nil {
+		return "", err
+	}
+	return resp.BodyAsString()
+}
@@ -0,0 +1,97 @@
+package bintray
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestSignVersion(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/gpg/subject/repository/pkg/versions/1.2", func(w http.ResponseWriter, r *http.Request) {
+		if m := "POST"; m != r.Method {
+			t.Errorf("Request method = %v, want %v", r.Method, m)
+		}
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["passphrase"] != "secret" || body["subject"] != "org" {
+			t.Errorf("unexpected request body %v", body)
+		}
+		testHeader(t, r, "X-GPG-PASSPHRASE", "")
+	})
+	err := client.SignVersion("subject", "repository", "pkg", "1.2", &SignOptions{Passphrase: "secret", KeyOwner: "org"})
+	if err != nil {
+		t.Errorf("unexpected error thrown %s", err)
+	}
+}
+
+func TestSignFile_passphraseHeader(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/gpg/subject/repository/a/file.deb", func(w http.ResponseWriter, r *http.Request) {
+		testHeader(t, r, "X-GPG-PASSPHRASE", "secret")
+		if r.ContentLength > 0 {
+			t.Errorf("unexpected request body")
+		}
+	})
+	err := client.SignFile("subject", "repository", "/a/file.deb", &SignOptions{Passphrase: "secret", PassphraseInHeader: true})
+	if err != nil {
+		t.Errorf("unexpected error thrown %s", err)
+	}
+}
+
+func TestGetSubjectPublicKey(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/users/org/keys/gpg/public.key", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"User 'org' was not found"}`, 404)
+	})
+	mux.HandleFunc("/orgs/org/keys/gpg/public.key", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "-----BEGIN PGP PUBLIC KEY BLOCK-----")
+	})
+	key, err := client.GetSubjectPublicKey("org")
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if key != "-----BEGIN PGP PUBLIC KEY BLOCK-----" {
+		t.Errorf("unexpected key %q", key)
+	}
+}
+
+func TestGetRepoPublicKey(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/repos/subject/repository/keys/gpg/public.key", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "-----BEGIN PGP PUBLIC KEY BLOCK-----")
+	})
+	key, err := client.GetRepoPublicKey("subject", "repository")
+	if err != nil || key != "-----BEGIN PGP PUBLIC KEY BLOCK-----" {
+		t.Errorf("unexpected key %q, %v", key, err)
+	}
+}
+
+func TestPublishWithOptions_sign(t *testing.T) {
+	setup()
+	defer teardown()
+	signed := false
+	mux.HandleFunc("/gpg/subject/repository/pkg/versions/1.2", func(w http.ResponseWriter, r *http.Request) {
+		signed = true
+	})
+	mux.HandleFunc("/content/subject/repository/pkg/1.2/publish", func(w http.ResponseWriter, r *http.Request) {
+		if !signed {
+			t.Errorf("version should be signed before publishing")
+		}
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if _, ok := body["Sign"]; ok {
+			t.Errorf("sign options should not be sent: %v", body)
+		}
+		fmt.Fprint(w, `{"files":2}`)
+	})
+	_, err := client.PublishWithOptions("subject", "repository", "pkg", "1.2", &PublishOptions{Sign: &SignOptions{}})
+	if err != nil {
+		t.Errorf("unexpected error thrown %s", err)
+	}
+}
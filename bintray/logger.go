@@ -0,0 +1,97 @@
+package bintray
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// RequestEvent describes an HTTP request sent to Bintray.
+// Retried requests produce an event for every attempt.
+type RequestEvent struct {
+	Method string
+	// URL of the request, without credentials.
+	URL string
+	// Path of the request URL.
+	Path string
+	// Status is the response status code, zero if no response was received.
+	Status int
+	// Duration of the round trip.
+	Duration time.Duration
+	// BytesSent is the request body size, -1 if unknown.
+	BytesSent int64
+	// BytesReceived is the response body size as declared by the server, -1 if unknown.
+	BytesReceived int64
+	// Attempt is the attempt number, starting from 1.
+	Attempt int
+	// Err is the transport error, if any.
+	Err error
+}
+
+// A Logger receives events about the requests sent by the Client.
+// The API key is never part of the events.
+type Logger interface {
+	LogRequest(e RequestEvent)
+}
+
+// NopLogger is a Logger discarding all events. It is the Client default.
+type NopLogger struct{}
+
+// LogRequest does nothing.
+func (NopLogger) LogRequest(e RequestEvent) {}
+
+type slogLogger struct {
+	l *slog.Logger
+}
+
+// NewSlogLogger returns a Logger writing events to l: successful requests
+// are logged at debug level, failed ones at warn level.
+func NewSlogLogger(l *slog.Logger) Logger {
+	return &slogLogger{l: l}
+}
+
+func (s *slogLogger) LogRequest(e RequestEvent) {
+	level := slog.LevelDebug
+	if e.Err != nil || e.Status >= 400 {
+		level = slog.LevelWarn
+	}
+	attrs := []slog.Attr{
+		slog.String("method", e.Method),
+		slog.String("path", e.Path),
+		slog.Int("status", e.Status),
+		slog.Duration("duration", e.Duration),
+		slog.Int64("bytes_sent", e.BytesSent),
+		slog.Int64("bytes_received", e.BytesReceived),
+		slog.Int("attempt", e.Attempt),
+	}
+	if e.Err != nil {
+		attrs = append(attrs, slog.String("error", e.Err.Error()))
+	}
+	s.l.LogAttrs(context.Background(), level, "bintray request", attrs...)
+}
+
+// redactedError hides the API key in the wrapped error message.
+type redactedError struct {
+	err    error
+	secret string
+}
+
+func (r *redactedError) Error() string {
+	return strings.Replace(r.err.Error(), r.secret, "REDACTED", -1)
+}
+
+func (r *redactedError) Unwrap() error {
+	return r.err
+}
+
+// logRequest sends the event to the client logger, hiding the API key used for the request.
+func (c *Client) logRequest(e RequestEvent, apikey string) {
+	if c.Logger == nil {
+		return
+	}
+	if e.Err != nil && apikey != "" && strings.Contains(e.Err.Error(), apikey) {
+		e.Err = &redactedError{err: e.Err, secret: apikey}
+	}
+	c.Logger.LogRequest(e)
+}
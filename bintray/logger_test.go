@@ -0,0 +1,69 @@
+package bintray
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type recordingLogger struct {
+	events []RequestEvent
+}
+
+func (r *recordingLogger) LogRequest(e RequestEvent) {
+	r.events = append(r.events, e)
+}
+
+func TestLogger(t *testing.T) {
+	setup()
+	defer teardown()
+	logger := &recordingLogger{}
+	client.Logger = logger
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Not Found", 404)
+	})
+	client.GetVersions("subject", "repository", "pkg")
+	if len(logger.events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(logger.events))
+	}
+	e := logger.events[0]
+	if e.Method != "GET" || e.Path != "/packages/subject/repository/pkg" || e.Status != 404 || e.Attempt != 1 || e.BytesSent != 0 {
+		t.Errorf("unexpected event %#v", e)
+	}
+}
+
+func TestLogger_redactsAPIKey(t *testing.T) {
+	logger := &recordingLogger{}
+	c := NewClient(nil, "sub", "secretkey")
+	c.Logger = logger
+	c.logRequest(RequestEvent{Err: errors.New("failure with secretkey inside")}, "secretkey")
+	if msg := logger.events[0].Err.Error(); strings.Contains(msg, "secretkey") {
+		t.Errorf("API key not redacted: %s", msg)
+	}
+}
+
+func TestSlogLogger(t *testing.T) {
+	setup()
+	defer teardown()
+	var buf bytes.Buffer
+	client.Logger = NewSlogLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	})
+	req, _ := client.newRequestWithBody(context.Background(), "POST", "/foo", "request_data")
+	client.execute(req)
+	out := buf.String()
+	for _, expected := range []string{"level=DEBUG", "method=POST", "path=/foo", "status=200", "bytes_sent=12", "bytes_received=2"} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("log %q should contain %q", out, expected)
+		}
+	}
+	if strings.Contains(out, "api") {
+		t.Errorf("log should not contain the API key: %q", out)
+	}
+}
@@ -0,0 +1,226 @@
+package bintray
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const defaultMavenExtension = "jar"
+
+// MavenCoordinates identify a Maven artifact.
+type MavenCoordinates struct {
+	GroupID    string
+	ArtifactID string
+	Version    string
+	Classifier string
+	// Extension of the artifact file, "jar" if empty.
+	Extension string
+}
+
+// ParseMavenCoordinates parses coordinates in the `groupId:artifactId:version[:classifier][@extension]` format.
+func ParseMavenCoordinates(s string) (MavenCoordinates, error) {
+	var m MavenCoordinates
+	gav := s
+	if i := strings.LastIndex(s, "@"); i >= 0 {
+		gav, m.Extension = s[:i], s[i+1:]
+		if m.Extension == "" {
+			return m, fmt.Errorf("Maven coordinates %q: empty extension", s)
+		}
+	}
+	parts := strings.Split(gav, ":")
+	if len(parts) < 3 || len(parts) > 4 {
+		return m, fmt.Errorf("Maven coordinates %q: expected groupId:artifactId:version[:classifier][@extension]", s)
+	}
+	m.GroupID, m.ArtifactID, m.Version = parts[0], parts[1], parts[2]
+	if len(parts) == 4 {
+		m.Classifier = parts[3]
+	}
+	for _, p := range parts {
+		if p == "" || strings.ContainsAny(p, "/\\") {
+			return m, fmt.Errorf("Maven coordinates %q: invalid element %q", s, p)
+		}
+	}
+	return m, nil
+}
+
+// String returns the coordinates in the `groupId:artifactId:version[:classifier][@extension]` format.
+func (m MavenCoordinates) String() string {
+	s := m.GroupID + ":" + m.ArtifactID + ":" + m.Version
+	if m.Classifier != "" {
+		s += ":" + m.Classifier
+	}
+	if m.Extension != "" {
+		s += "@" + m.Extension
+	}
+	return s
+}
+
+// Path returns the path of the artifact in a Maven repository,
+// ie `org/example/lib/1.0/lib-1.0-sources.jar`.
+func (m MavenCoordinates) Path() string {
+	ext := m.Extension
+	if ext == "" {
+		ext = defaultMavenExtension
+	}
+	name := m.ArtifactID + "-" + m.Version
+	if m.Classifier != "" {
+		name += "-" + m.Classifier
+	}
+	return m.dir() + "/" + name + "." + ext
+}
+
+// dir returns the directory of the version in a Maven repository.
+func (m MavenCoordinates) dir() string {
+	return strings.Replace(m.GroupID, ".", "/", -1) + "/" + m.ArtifactID + "/" + m.Version
+}
+
+// with returns the coordinates of another artifact of the same version.
+func (m MavenCoordinates) with(classifier, extension string) MavenCoordinates {
+	m.Classifier, m.Extension = classifier, extension
+	return m
+}
+
+// MavenUploadRequest describes the upload of the artifacts of a Maven version.
+type MavenUploadRequest struct {
+	Subject    string
+	Repository string
+	Package    string
+
+	// Coordinates of the main artifact, in the `groupId:artifactId:version[:classifier][@extension]` format.
+	Coordinates string
+
+	// Main is the content of the main artifact.
+	// It can be nil uploading a POM only version, with the `pom` extension.
+	Main io.Reader
+
+	// Sources and Javadoc, if not nil, are uploaded as the `sources` and `javadoc` jars.
+	Sources io.Reader
+	Javadoc io.Reader
+
+	// POM is the project descriptor. If nil, a minimal one is generated.
+	POM io.Reader
+
+	// Publish publishes the files right after the upload.
+	Publish bool
+
+	// Signer, if not nil, signs every file uploading the `.asc` signatures.
+	Signer *Signer
+
+	// Progress, if not nil, is notified while each file is sent.
+	Progress ProgressReporter
+}
+
+// MavenUploadResult reports the outcome of a Maven upload.
+type MavenUploadResult struct {
+	Coordinates MavenCoordinates
+
+	// Files are the results of the uploaded artifacts, in upload order.
+	Files []*UploadResult
+}
+
+// pom is the minimal project descriptor generated for a Maven upload.
+type pom struct {
+	XMLName      xml.Name `xml:"project"`
+	Xmlns        string   `xml:"xmlns,attr"`
+	ModelVersion string   `xml:"modelVersion"`
+	GroupID      string   `xml:"groupId"`
+	ArtifactID   string   `xml:"artifactId"`
+	Version      string   `xml:"version"`
+	Packaging    string   `xml:"packaging,omitempty"`
+}
+
+// generatePOM returns a minimal POM for the given coordinates.
+// The packaging is the extension of the main artifact: a classified artifact,
+// ie `-sources.jar` or `-dist.zip`, doesn't tell it and `jar` is used.
+func generatePOM(m MavenCoordinates) ([]byte, error) {
+	packaging := m.Extension
+	if packaging == "" || m.Classifier != "" {
+		packaging = defaultMavenExtension
+	}
+	p := pom{
+		Xmlns:        "http://maven.apache.org/POM/4.0.0",
+		ModelVersion: "4.0.0",
+		GroupID:      m.GroupID,
+		ArtifactID:   m.ArtifactID,
+		Version:      m.Version,
+		Packaging:    packaging,
+	}
+	data, err := xml.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(data, '\n')...), nil
+}
+
+// UploadMaven uploads the artifacts of a Maven version with the Maven API, which
+// creates the version if missing: the main artifact, sources, javadoc and the POM,
+// each one followed by its `.md5`, `.sha1` and `.sha256` checksum files.
+// PUT /maven/:subject/:repo/:package/:file_path
+func (c *Client) UploadMaven(ctx context.Context, r *MavenUploadRequest) (*MavenUploadResult, error) {
+	if r == nil || r.Subject == "" || r.Repository == "" || r.Package == "" {
+		return nil, errors.New("UploadMaven: subject, repository and package name shouldn't be empty")
+	}
+	coordinates, err := ParseMavenCoordinates(r.Coordinates)
+	if err != nil {
+		return nil, err
+	}
+	pomOnly := coordinates.Extension == "pom" && coordinates.Classifier == ""
+	if r.Main == nil && !pomOnly {
+		return nil, errors.New("UploadMaven: main artifact shouldn't be empty")
+	}
+	type artifact struct {
+		coordinates MavenCoordinates
+		body        io.Reader
+	}
+	var artifacts []artifact
+	if r.Main != nil {
+		artifacts = append(artifacts, artifact{coordinates, r.Main})
+	}
+	if r.Sources != nil {
+		artifacts = append(artifacts, artifact{coordinates.with("sources", "jar"), r.Sources})
+	}
+	if r.Javadoc != nil {
+		artifacts = append(artifacts, artifact{coordinates.with("javadoc", "jar"), r.Javadoc})
+	}
+	if !(pomOnly && r.Main != nil) {
+		// the POM, unless it is the main artifact
+		descriptor := r.POM
+		if descriptor == nil {
+			data, err := generatePOM(coordinates)
+			if err != nil {
+				return nil, err
+			}
+			descriptor = bytes.NewReader(data)
+		}
+		artifacts = append(artifacts, artifact{coordinates.with("", "pom"), descriptor})
+	}
+
+	result := &MavenUploadResult{Coordinates: coordinates}
+	for _, a := range artifacts {
+		uploaded, err := c.Upload(ctx, &UploadRequest{
+			Subject:    r.Subject,
+			Repository: r.Repository,
+			Package:    r.Package,
+			Version:    coordinates.Version,
+			Path:       a.coordinates.Path(),
+			Body:       a.body,
+			Options: UploadOptions{
+				Publish:       r.Publish,
+				ChecksumFiles: true,
+				Signer:        r.Signer,
+			},
+			Progress: r.Progress,
+			maven:    true,
+		})
+		if err != nil {
+			return result, err
+		}
+		result.Files = append(result.Files, uploaded)
+	}
+	return result, nil
+}
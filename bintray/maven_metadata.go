@@ -0,0 +1,393 @@
+package bintray
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// mavenTimestampFormat is the format of lastUpdated in maven-metadata.xml.
+const mavenTimestampFormat = "20060102150405"
+
+// MavenMetadata is the content of a maven-metadata.xml file.
+type MavenMetadata struct {
+	GroupID    string          `xml:"groupId"`
+	ArtifactID string          `xml:"artifactId"`
+	Version    string          `xml:"version,omitempty"`
+	Versioning MavenVersioning `xml:"versioning"`
+}
+
+// MavenVersioning lists the versions of an artifact or, in the metadata of a
+// snapshot version, its timestamped builds.
+type MavenVersioning struct {
+	Latest           string                 `xml:"latest,omitempty"`
+	Release          string                 `xml:"release,omitempty"`
+	Versions         []string               `xml:"versions>version"`
+	LastUpdated      string                 `xml:"lastUpdated,omitempty"`
+	Snapshot         *MavenSnapshot         `xml:"snapshot"`
+	SnapshotVersions []MavenSnapshotVersion `xml:"snapshotVersions>snapshotVersion"`
+}
+
+// MavenSnapshot is the last build of a snapshot version.
+type MavenSnapshot struct {
+	Timestamp   string `xml:"timestamp"`
+	BuildNumber int    `xml:"buildNumber"`
+	LocalCopy   bool   `xml:"localCopy,omitempty"`
+}
+
+// MavenSnapshotVersion is the timestamped version of a snapshot artifact.
+type MavenSnapshotVersion struct {
+	Classifier string `xml:"classifier,omitempty"`
+	Extension  string `xml:"extension"`
+	Value      string `xml:"value"`
+	Updated    string `xml:"updated"`
+}
+
+// LastUpdatedTime returns the parsed LastUpdated, in UTC.
+func (v MavenVersioning) LastUpdatedTime() (time.Time, error) {
+	return time.Parse(mavenTimestampFormat, v.LastUpdated)
+}
+
+// GetMavenMetadata downloads and parses the maven-metadata.xml of an artifact.
+// GET https://dl.bintray.com/:subject/:repo/:group_path/:artifact_id/maven-metadata.xml
+func (c *Client) GetMavenMetadata(subject, repository, groupID, artifactID string) (*MavenMetadata, error) {
+	return c.GetMavenMetadataContext(context.Background(), subject, repository, groupID, artifactID)
+}
+
+// GetMavenMetadataContext is like GetMavenMetadata but uses the given context for the request.
+func (c *Client) GetMavenMetadataContext(ctx context.Context, subject, repository, groupID, artifactID string) (*MavenMetadata, error) {
+	if subject == "" || repository == "" || groupID == "" || artifactID == "" {
+		return nil, errors.New("GetMavenMetadata: subject, repository, group id and artifact id shouldn't be empty")
+	}
+	m := MavenCoordinates{GroupID: groupID, ArtifactID: artifactID}
+	filePath := strings.Replace(m.GroupID, ".", "/", -1) + "/" + m.ArtifactID + "/maven-metadata.xml"
+	req, err := c.newDownloadRequest(ctx, subject, repository, filePath)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.execute(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	metadata := new(MavenMetadata)
+	if err := xml.NewDecoder(resp.Body).Decode(metadata); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// ResolveVersion returns the version matching spec, which can be:
+//
+//   - LATEST or RELEASE
+//   - a version, returned if listed in the metadata
+//   - a Maven version range, ie `[1.2,2.0)` or `(,1.0],[1.2,)`: the highest listed
+//     version in the range is returned
+//
+// An error matching ErrNoMatchingVersion is returned if no version matches.
+func (m *MavenMetadata) ResolveVersion(spec string) (string, error) {
+	spec = strings.TrimSpace(spec)
+	switch spec {
+	case "LATEST":
+		if m.Versioning.Latest != "" {
+			return m.Versioning.Latest, nil
+		}
+		return m.highest(spec, func(string) bool { return true })
+	case "RELEASE":
+		if m.Versioning.Release != "" {
+			return m.Versioning.Release, nil
+		}
+		return m.highest(spec, func(v string) bool { return !strings.HasSuffix(v, "-SNAPSHOT") })
+	}
+	if !strings.HasPrefix(spec, "[") && !strings.HasPrefix(spec, "(") {
+		return m.highest(spec, func(v string) bool { return v == spec })
+	}
+	ranges, err := parseVersionRanges(spec)
+	if err != nil {
+		return "", err
+	}
+	return m.highest(spec, func(v string) bool {
+		for _, r := range ranges {
+			if r.contains(v) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// highest returns the highest listed version accepted by match.
+func (m *MavenMetadata) highest(spec string, match func(string) bool) (string, error) {
+	found := ""
+	for _, v := range m.Versioning.Versions {
+		if match(v) && (found == "" || CompareMavenVersions(v, found) > 0) {
+			found = v
+		}
+	}
+	if found == "" {
+		return "", fmt.Errorf("%w: %s in %s:%s", ErrNoMatchingVersion, spec, m.GroupID, m.ArtifactID)
+	}
+	return found, nil
+}
+
+// versionRange is a Maven version range, with empty bounds for open ranges.
+type versionRange struct {
+	lower, upper                   string
+	lowerInclusive, upperInclusive bool
+}
+
+func (r versionRange) contains(v string) bool {
+	if r.lower != "" {
+		c := CompareMavenVersions(v, r.lower)
+		if c < 0 || (c == 0 && !r.lowerInclusive) {
+			return false
+		}
+	}
+	if r.upper != "" {
+		c := CompareMavenVersions(v, r.upper)
+		if c > 0 || (c == 0 && !r.upperInclusive) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseVersionRanges parses a comma separated list of ranges, ie `(,1.0],[1.2,)`.
+func parseVersionRanges(spec string) ([]versionRange, error) {
+	var ranges []versionRange
+	rest := spec
+	for rest != "" {
+		end := strings.IndexAny(rest, "])")
+		if end < 0 || (rest[0] != '[' && rest[0] != '(') {
+			return nil, fmt.Errorf("invalid version range %q", spec)
+		}
+		r := versionRange{lowerInclusive: rest[0] == '[', upperInclusive: rest[end] == ']'}
+		bounds := strings.TrimSpace(rest[1:end])
+		if i := strings.Index(bounds, ","); i >= 0 {
+			r.lower, r.upper = strings.TrimSpace(bounds[:i]), strings.TrimSpace(bounds[i+1:])
+			if strings.Contains(r.upper, ",") || (r.lower != "" && r.upper != "" && CompareMavenVersions(r.lower, r.upper) > 0) {
+				return nil, fmt.Errorf("invalid version range %q", spec)
+			}
+		} else {
+			// [1.0] is the exact version
+			if bounds == "" || !r.lowerInclusive || !r.upperInclusive {
+				return nil, fmt.Errorf("invalid version range %q", spec)
+			}
+			r.lower, r.upper = bounds, bounds
+		}
+		ranges = append(ranges, r)
+		rest = strings.TrimSpace(rest[end+1:])
+		if rest != "" {
+			if rest[0] != ',' {
+				return nil, fmt.Errorf("invalid version range %q", spec)
+			}
+			rest = strings.TrimSpace(rest[1:])
+		}
+	}
+	return ranges, nil
+}
+
+// CompareMavenVersions compares two versions following the Maven ordering, where
+// ie `1.0-alpha-1 < 1.0-beta < 1.0-rc1 < 1.0-SNAPSHOT < 1.0 < 1.0-sp1 < 1.0.1`.
+// It returns -1, 0 or 1 if a is lower, equal or greater than b.
+func CompareMavenVersions(a, b string) int {
+	return parseMavenVersion(a).compare(parseMavenVersion(b))
+}
+
+// mavenItem is an element of a parsed version: an integer, a qualifier or a sublist.
+// A nil mavenItem is the missing element, compared as 0 or the release qualifier.
+type mavenItem interface {
+	compare(other mavenItem) int
+	isNull() bool
+}
+
+type mavenInt uint64
+
+type mavenString string
+
+type mavenList []mavenItem
+
+// mavenQualifiers are the known qualifiers in order, the empty one being the release.
+var mavenQualifiers = []string{"alpha", "beta", "milestone", "rc", "snapshot", "", "sp"}
+
+var mavenQualifierAliases = map[string]string{"ga": "", "final": "", "release": "", "cr": "rc"}
+
+func newMavenString(s string, followedByDigit bool) mavenString {
+	if followedByDigit && len(s) == 1 {
+		switch s {
+		case "a":
+			s = "alpha"
+		case "b":
+			s = "beta"
+		case "m":
+			s = "milestone"
+		}
+	}
+	if alias, ok := mavenQualifierAliases[s]; ok {
+		s = alias
+	}
+	return mavenString(s)
+}
+
+// comparable returns a string ordering known qualifiers before unknown ones.
+func (s mavenString) comparable() string {
+	for i, q := range mavenQualifiers {
+		if string(s) == q {
+			return strconv.Itoa(i)
+		}
+	}
+	return strconv.Itoa(len(mavenQualifiers)) + "-" + string(s)
+}
+
+func (i mavenInt) isNull() bool    { return i == 0 }
+func (s mavenString) isNull() bool { return s == "" }
+func (l mavenList) isNull() bool   { return len(l) == 0 }
+
+func (i mavenInt) compare(other mavenItem) int {
+	switch o := other.(type) {
+	case nil:
+		if i == 0 {
+			return 0
+		}
+		return 1
+	case mavenInt:
+		switch {
+		case i < o:
+			return -1
+		case i > o:
+			return 1
+		}
+		return 0
+	}
+	// 1.1 > 1-sp > 1-1
+	return 1
+}
+
+func (s mavenString) compare(other mavenItem) int {
+	switch o := other.(type) {
+	case nil:
+		return strings.Compare(s.comparable(), mavenString("").comparable())
+	case mavenString:
+		return strings.Compare(s.comparable(), o.comparable())
+	}
+	return -1
+}
+
+func (l mavenList) compare(other mavenItem) int {
+	switch o := other.(type) {
+	case nil:
+		if len(l) == 0 {
+			return 0
+		}
+		return l[0].compare(nil)
+	case mavenInt:
+		return -1
+	case mavenString:
+		return 1
+	case mavenList:
+		for i := 0; i < len(l) || i < len(o); i++ {
+			var left, right mavenItem
+			if i < len(l) {
+				left = l[i]
+			}
+			if i < len(o) {
+				right = o[i]
+			}
+			var c int
+			if left == nil {
+				if right != nil {
+					c = -right.compare(nil)
+				}
+			} else {
+				c = left.compare(right)
+			}
+			if c != 0 {
+				return c
+			}
+		}
+	}
+	return 0
+}
+
+// normalize removes the trailing null items, ie `1.0.0` becomes `1`.
+func (l mavenList) normalize() mavenList {
+	for i := len(l) - 1; i >= 0; i-- {
+		if l[i].isNull() {
+			l = append(l[:i], l[i+1:]...)
+		} else if _, ok := l[i].(mavenList); !ok {
+			break
+		}
+	}
+	return l
+}
+
+// parseMavenVersion parses a version as Maven does: `.` separates items, while
+// `-` and transitions between digits and letters start a sublist.
+func parseMavenVersion(version string) mavenList {
+	version = strings.ToLower(version)
+	// lists are built bottom up: stack[0] is the root
+	stack := []mavenList{{}}
+	push := func(item mavenItem) {
+		stack[len(stack)-1] = append(stack[len(stack)-1], item)
+	}
+	sublist := func() {
+		stack = append(stack, mavenList{})
+	}
+	parseItem := func(s string, isDigit, followedByDigit bool) mavenItem {
+		if isDigit {
+			n, _ := strconv.ParseUint(strings.TrimLeft(s, "0"), 10, 64)
+			return mavenInt(n)
+		}
+		return newMavenString(s, followedByDigit)
+	}
+	isDigit := false
+	start := 0
+	for i := 0; i < len(version); i++ {
+		c := version[i]
+		switch {
+		case c == '.':
+			if i == start {
+				push(mavenInt(0))
+			} else {
+				push(parseItem(version[start:i], isDigit, false))
+			}
+			start = i + 1
+		case c == '-':
+			if i == start {
+				push(mavenInt(0))
+			} else {
+				push(parseItem(version[start:i], isDigit, false))
+			}
+			start = i + 1
+			sublist()
+		case c >= '0' && c <= '9':
+			if !isDigit && i > start {
+				push(newMavenString(version[start:i], true))
+				start = i
+				sublist()
+			}
+			isDigit = true
+		default:
+			if isDigit && i > start {
+				push(parseItem(version[start:i], true, false))
+				start = i
+				sublist()
+			}
+			isDigit = false
+		}
+	}
+	if len(version) > start {
+		push(parseItem(version[start:], isDigit, false))
+	}
+	// close the sublists, each one being the last item of its parent
+	for len(stack) > 1 {
+		last := stack[len(stack)-1].normalize()
+		stack = stack[:len(stack)-1]
+		push(last)
+	}
+	return stack[0].normalize()
+}
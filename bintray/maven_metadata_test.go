@@ -0,0 +1,138 @@
+package bintray
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const mavenMetadataResp = `<?xml version="1.0" encoding="UTF-8"?>
+<metadata>
+  <groupId>org.example</groupId>
+  <artifactId>lib</artifactId>
+  <versioning>
+    <latest>2.1-SNAPSHOT</latest>
+    <release>2.0</release>
+    <versions>
+      <version>1.0</version>
+      <version>1.2-beta-1</version>
+      <version>1.2</version>
+      <version>1.10</version>
+      <version>2.0-rc1</version>
+      <version>2.0</version>
+      <version>2.1-SNAPSHOT</version>
+    </versions>
+    <lastUpdated>20160102030405</lastUpdated>
+  </versioning>
+</metadata>`
+
+func TestGetMavenMetadata(t *testing.T) {
+	setup()
+	defer teardown()
+	client.downloadsHost = server.URL + "/dl/"
+	mux.HandleFunc("/dl/subject/repository/org/example/lib/maven-metadata.xml", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, mavenMetadataResp)
+	})
+	m, err := client.GetMavenMetadata("subject", "repository", "org.example", "lib")
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if m.GroupID != "org.example" || m.ArtifactID != "lib" || m.Versioning.Release != "2.0" || len(m.Versioning.Versions) != 7 {
+		t.Errorf("unexpected metadata %#v", m)
+	}
+	updated, err := m.Versioning.LastUpdatedTime()
+	if err != nil || !updated.Equal(time.Date(2016, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Errorf("LastUpdatedTime() = %v, %v", updated, err)
+	}
+}
+
+func TestMavenMetadata_snapshot(t *testing.T) {
+	m := new(MavenMetadata)
+	err := xml.Unmarshal([]byte(`<metadata><groupId>org.example</groupId><artifactId>lib</artifactId><version>1.0-SNAPSHOT</version>
+<versioning><snapshot><timestamp>20160102.030405</timestamp><buildNumber>3</buildNumber></snapshot>
+<snapshotVersions><snapshotVersion><extension>jar</extension><value>1.0-20160102.030405-3</value><updated>20160102030405</updated></snapshotVersion></snapshotVersions>
+</versioning></metadata>`), m)
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if m.Versioning.Snapshot == nil || m.Versioning.Snapshot.BuildNumber != 3 {
+		t.Errorf("unexpected snapshot %#v", m.Versioning.Snapshot)
+	}
+	expected := []MavenSnapshotVersion{{Extension: "jar", Value: "1.0-20160102.030405-3", Updated: "20160102030405"}}
+	if !reflect.DeepEqual(m.Versioning.SnapshotVersions, expected) {
+		t.Errorf("SnapshotVersions = %#v, want %#v", m.Versioning.SnapshotVersions, expected)
+	}
+}
+
+func TestMavenMetadata_ResolveVersion(t *testing.T) {
+	m := new(MavenMetadata)
+	if err := xml.Unmarshal([]byte(mavenMetadataResp), m); err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	tests := []struct {
+		spec     string
+		expected string
+	}{
+		{"LATEST", "2.1-SNAPSHOT"},
+		{"RELEASE", "2.0"},
+		{"1.2", "1.2"},
+		{"[1.0]", "1.0"},
+		{"[1.2,2.0)", "2.0-rc1"},
+		{"[1.0,1.2)", "1.2-beta-1"},
+		{"(,1.2]", "1.2"},
+		{"[1.2,1.10]", "1.10"},
+		{"[1.0,1.2),(2.0,)", "2.1-SNAPSHOT"},
+		{"(2.0,2.1-SNAPSHOT)", ""},
+		{"1.3", ""},
+	}
+	for _, tt := range tests {
+		v, err := m.ResolveVersion(tt.spec)
+		if tt.expected == "" {
+			if !errors.Is(err, ErrNoMatchingVersion) {
+				t.Errorf("%s: expected ErrNoMatchingVersion, got %v %v", tt.spec, v, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error thrown %s", tt.spec, err)
+			continue
+		}
+		if v != tt.expected {
+			t.Errorf("%s: resolved %s, want %s", tt.spec, v, tt.expected)
+		}
+	}
+	for _, invalid := range []string{"[1.0", "[2.0,1.0]", "(1.0)", "[1.0,2.0]x", "[,]x"} {
+		if _, err := m.ResolveVersion(invalid); err == nil || errors.Is(err, ErrNoMatchingVersion) {
+			t.Errorf("%s: expected invalid range error, got %v", invalid, err)
+		}
+	}
+}
+
+func TestCompareMavenVersions(t *testing.T) {
+	ordered := []string{
+		"1-alpha-1", "1-alpha2", "1-beta", "1-b2", "1-milestone", "1-m2", "1-rc", "1-cr2", "1-SNAPSHOT",
+		"1", "1-sp", "1-sp2", "1-abc", "1-1", "1.1", "1.2", "1.10", "2.0-rc1", "2",
+	}
+	for i := range ordered {
+		for j := range ordered {
+			expected := 0
+			if i < j {
+				expected = -1
+			} else if i > j {
+				expected = 1
+			}
+			if c := CompareMavenVersions(ordered[i], ordered[j]); c != expected {
+				t.Errorf("compare(%s, %s) = %d, want %d", ordered[i], ordered[j], c, expected)
+			}
+		}
+	}
+	for _, equal := range [][2]string{{"1", "1.0.0"}, {"1-ga", "1"}, {"1.0-final", "1"}, {"1-0.0", "1"}, {"1.0-RELEASE", "1"}, {"1-CR1", "1-rc1"}} {
+		if c := CompareMavenVersions(equal[0], equal[1]); c != 0 {
+			t.Errorf("compare(%s, %s) = %d, want 0", equal[0], equal[1], c)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package bintray
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestParseMavenCoordinates(t *testing.T) {
+	tests := []struct {
+		coordinates string
+		expected    MavenCoordinates
+		path        string
+	}{
+		{"org.example:lib:1.0", MavenCoordinates{"org.example", "lib", "1.0", "", ""}, "org/example/lib/1.0/lib-1.0.jar"},
+		{"org.example:lib:1.0:tests", MavenCoordinates{"org.example", "lib", "1.0", "tests", ""}, "org/example/lib/1.0/lib-1.0-tests.jar"},
+		{"org.example:lib:1.0@war", MavenCoordinates{"org.example", "lib", "1.0", "", "war"}, "org/example/lib/1.0/lib-1.0.war"},
+		{"org.example:lib:1.0:linux@tar.gz", MavenCoordinates{"org.example", "lib", "1.0", "linux", "tar.gz"}, "org/example/lib/1.0/lib-1.0-linux.tar.gz"},
+	}
+	for _, tt := range tests {
+		m, err := ParseMavenCoordinates(tt.coordinates)
+		if err != nil {
+			t.Errorf("%s: unexpected error thrown %s", tt.coordinates, err)
+			continue
+		}
+		if m != tt.expected {
+			t.Errorf("%s: parsed %#v, want %#v", tt.coordinates, m, tt.expected)
+		}
+		if m.Path() != tt.path {
+			t.Errorf("%s: path %q, want %q", tt.coordinates, m.Path(), tt.path)
+		}
+		if m.String() != tt.coordinates {
+			t.Errorf("%s: String() = %q", tt.coordinates, m.String())
+		}
+	}
+	for _, invalid := range []string{"", "org.example:lib", "org.example:lib:1.0:a:b", "org.example::1.0", "org.example:lib:1.0@", "org/example:lib:1.0"} {
+		if _, err := ParseMavenCoordinates(invalid); err == nil {
+			t.Errorf("%q: expected error", invalid)
+		}
+	}
+}
+
+func TestGeneratePOM_packaging(t *testing.T) {
+	tests := map[string]string{
+		"org.example:lib:1.0":              "jar",
+		"org.example:lib:1.0@war":          "war",
+		"org.example:lib:1.0@pom":          "pom",
+		"org.example:lib:1.0:sources":      "jar",
+		"org.example:lib:1.0:dist@zip":     "jar",
+		"org.example:lib:1.0:linux@tar.gz": "jar",
+	}
+	for coordinates, packaging := range tests {
+		m, _ := ParseMavenCoordinates(coordinates)
+		pom, err := generatePOM(m)
+		if err != nil {
+			t.Errorf("%s: unexpected error thrown %s", coordinates, err)
+			continue
+		}
+		if !strings.Contains(string(pom), "<packaging>"+packaging+"</packaging>") {
+			t.Errorf("%s: packaging should be %s\n%s", coordinates, packaging, pom)
+		}
+	}
+}
+
+func TestUploadMaven(t *testing.T) {
+	setup()
+	defer teardown()
+	var mu sync.Mutex
+	uploaded := map[string]string{}
+	mux.HandleFunc("/maven/subject/repository/lib/", func(w http.ResponseWriter, r *http.Request) {
+		if m := "PUT"; m != r.Method {
+			t.Errorf("Request method = %v, want %v", r.Method, m)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		mu.Lock()
+		uploaded[strings.TrimPrefix(r.URL.Path, "/maven/subject/repository/lib/")] = string(body)
+		mu.Unlock()
+		w.WriteHeader(201)
+	})
+	result, err := client.UploadMaven(context.Background(), &MavenUploadRequest{
+		Subject:     "subject",
+		Repository:  "repository",
+		Package:     "lib",
+		Coordinates: "org.example:lib:1.0",
+		Main:        strings.NewReader("main jar"),
+		Sources:     strings.NewReader("sources jar"),
+		Publish:     true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if len(result.Files) != 3 {
+		t.Errorf("%d files uploaded, want 3", len(result.Files))
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	var paths []string
+	for p := range uploaded {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	var expected []string
+	for _, f := range []string{"lib-1.0.jar", "lib-1.0-sources.jar", "lib-1.0.pom"} {
+		for _, ext := range []string{"", ".md5", ".sha1", ".sha256"} {
+			expected = append(expected, "org/example/lib/1.0/"+f+ext+";publish=1")
+		}
+	}
+	sort.Strings(expected)
+	if strings.Join(paths, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("uploaded\n%s\nwant\n%s", strings.Join(paths, "\n"), strings.Join(expected, "\n"))
+	}
+	if sha1 := uploaded["org/example/lib/1.0/lib-1.0.jar.sha1;publish=1"]; sha1 != sha1Of("main jar") {
+		t.Errorf("sha1 file content %q", sha1)
+	}
+	pom := uploaded["org/example/lib/1.0/lib-1.0.pom;publish=1"]
+	if !strings.Contains(pom, "<artifactId>lib</artifactId>") || !strings.Contains(pom, "<packaging>jar</packaging>") {
+		t.Errorf("unexpected generated pom\n%s", pom)
+	}
+}
+
+func TestUploadMaven_pomOnly(t *testing.T) {
+	setup()
+	defer teardown()
+	var mu sync.Mutex
+	var paths []string
+	mux.HandleFunc("/maven/subject/repository/parent/", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		w.WriteHeader(201)
+	})
+	_, err := client.UploadMaven(context.Background(), &MavenUploadRequest{
+		Subject:     "subject",
+		Repository:  "repository",
+		Package:     "parent",
+		Coordinates: "org.example:parent:1.0@pom",
+		Main:        strings.NewReader("<project/>"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(paths) != 4 || paths[0] != "/maven/subject/repository/parent/org/example/parent/1.0/parent-1.0.pom" {
+		t.Errorf("unexpected uploads %v", paths)
+	}
+
+	_, err = client.UploadMaven(context.Background(), &MavenUploadRequest{
+		Subject: "subject", Repository: "repository", Package: "lib",
+		Coordinates: "org.example:lib:1.0",
+	})
+	if err == nil {
+		t.Errorf("expected error without main artifact")
+	}
+}
@@ -0,0 +1,173 @@
+package bintray
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// An Option configures a Client created with New.
+type Option func(*clientOptions) error
+
+type clientOptions struct {
+	httpClient      *http.Client
+	baseURL         *url.URL
+	downloadHost    string
+	userAgentSuffix string
+	timeout         time.Duration
+	retryPolicy     *RetryPolicy
+	logger          Logger
+	subject         string
+	apikey          string
+	credentials     CredentialsProvider
+}
+
+// New returns a new Client configured with the given options.
+// Without options it is equivalent to NewClient(nil, "", "").
+func New(opts ...Option) (*Client, error) {
+	o := &clientOptions{}
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+	httpClient := o.httpClient
+	if o.timeout > 0 {
+		if httpClient == nil {
+			httpClient = http.DefaultClient
+		}
+		// never change the timeout of a shared client
+		withTimeout := *httpClient
+		withTimeout.Timeout = o.timeout
+		httpClient = &withTimeout
+	}
+	c := NewClient(httpClient, o.subject, o.apikey)
+	if o.baseURL != nil {
+		c.BaseURL = o.baseURL
+	}
+	if o.downloadHost != "" {
+		c.downloadsHost = o.downloadHost
+	}
+	if o.userAgentSuffix != "" {
+		c.UserAgent += " " + o.userAgentSuffix
+	}
+	c.RetryPolicy = o.retryPolicy
+	c.CredentialsProvider = o.credentials
+	if o.logger != nil {
+		c.Logger = o.logger
+	}
+	return c, nil
+}
+
+// WithHTTPClient sets the HTTP client used to communicate with the API.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *clientOptions) error {
+		if httpClient == nil {
+			return errors.New("bintray: nil HTTP client")
+		}
+		o.httpClient = httpClient
+		return nil
+	}
+}
+
+// WithBaseURL sets the base URL for API requests, ie the Bintray Enterprise endpoint.
+// The URL must be absolute and have a trailing slash.
+func WithBaseURL(baseURL string) Option {
+	return func(o *clientOptions) error {
+		u, err := parseEndpoint(baseURL)
+		if err != nil {
+			return fmt.Errorf("bintray: invalid base URL: %v", err)
+		}
+		o.baseURL = u
+		return nil
+	}
+}
+
+// WithDownloadHost sets the host files are downloaded from.
+// The URL must be absolute and have a trailing slash.
+func WithDownloadHost(downloadHost string) Option {
+	return func(o *clientOptions) error {
+		if _, err := parseEndpoint(downloadHost); err != nil {
+			return fmt.Errorf("bintray: invalid download host: %v", err)
+		}
+		o.downloadHost = downloadHost
+		return nil
+	}
+}
+
+// WithUserAgentSuffix appends the given string to the library user agent, ie `my-tool/1.2`.
+func WithUserAgentSuffix(suffix string) Option {
+	return func(o *clientOptions) error {
+		o.userAgentSuffix = strings.TrimSpace(suffix)
+		return nil
+	}
+}
+
+// WithTimeout sets the timeout of every request, including reading the response body.
+// The HTTP client is copied, so that a shared client is left untouched.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) error {
+		if timeout < 0 {
+			return errors.New("bintray: negative timeout")
+		}
+		o.timeout = timeout
+		return nil
+	}
+}
+
+// WithRetryPolicy sets the policy used to retry failed requests.
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(o *clientOptions) error {
+		o.retryPolicy = policy
+		return nil
+	}
+}
+
+// WithLogger sets the Logger receiving the request events.
+func WithLogger(logger Logger) Option {
+	return func(o *clientOptions) error {
+		o.logger = logger
+		return nil
+	}
+}
+
+// WithCredentials sets the subject and API key used to authenticate requests.
+func WithCredentials(subject, apikey string) Option {
+	return func(o *clientOptions) error {
+		if subject == "" || apikey == "" {
+			return errors.New("bintray: subject and API key shouldn't be empty")
+		}
+		o.subject = subject
+		o.apikey = apikey
+		return nil
+	}
+}
+
+// parseEndpoint parses an absolute http(s) URL with a trailing slash.
+func parseEndpoint(s string) (*url.URL, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("%q is not an absolute http URL", s)
+	}
+	if !strings.HasSuffix(u.Path, "/") {
+		return nil, fmt.Errorf("%q must have a trailing slash", s)
+	}
+	return u, nil
+}
+
+// WithCredentialsProvider sets the provider consulted for the credentials of every request.
+func WithCredentialsProvider(provider CredentialsProvider) Option {
+	return func(o *clientOptions) error {
+		if provider == nil {
+			return errors.New("bintray: nil credentials provider")
+		}
+		o.credentials = provider
+		return nil
+	}
+}
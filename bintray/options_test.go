@@ -0,0 +1,113 @@
+package bintray
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNew(t *testing.T) {
+	httpClient := &http.Client{}
+	logger := &recordingLogger{}
+	policy := DefaultRetryPolicy()
+	c, err := New(
+		WithHTTPClient(httpClient),
+		WithBaseURL("https://bintray.example.com/api/v1/"),
+		WithDownloadHost("https://dl.example.com/"),
+		WithUserAgentSuffix("my-tool/1.2"),
+		WithTimeout(time.Minute),
+		WithRetryPolicy(policy),
+		WithLogger(logger),
+		WithCredentials("sub", "key"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if c.BaseURL.String() != "https://bintray.example.com/api/v1/" {
+		t.Errorf("BaseURL = %v", c.BaseURL)
+	}
+	if c.downloadsHost != "https://dl.example.com/" {
+		t.Errorf("downloadsHost = %v", c.downloadsHost)
+	}
+	if c.UserAgent != userAgent+" my-tool/1.2" {
+		t.Errorf("UserAgent = %v", c.UserAgent)
+	}
+	if c.client.Timeout != time.Minute || httpClient.Timeout != 0 {
+		t.Errorf("timeout should be set on a copy of the HTTP client")
+	}
+	if c.RetryPolicy != policy || c.Logger != logger {
+		t.Errorf("retry policy or logger not set")
+	}
+	if c.subject != "sub" || c.apikey != "key" {
+		t.Errorf("credentials not set")
+	}
+}
+
+func TestNew_defaults(t *testing.T) {
+	c, err := New()
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if c.BaseURL.String() != defaultBaseURL || c.downloadsHost != defaultDownloadHost || c.UserAgent != userAgent {
+		t.Errorf("unexpected defaults %#v", c)
+	}
+	if c.client != http.DefaultClient {
+		t.Errorf("expected http.DefaultClient")
+	}
+}
+
+func TestNew_invalid(t *testing.T) {
+	invalid := []Option{
+		WithBaseURL("https://api.bintray.com"),
+		WithBaseURL("api.bintray.com/"),
+		WithDownloadHost("ftp://dl.bintray.com/"),
+		WithHTTPClient(nil),
+		WithTimeout(-time.Second),
+		WithCredentials("sub", ""),
+	}
+	for i, opt := range invalid {
+		if _, err := New(opt); err == nil {
+			t.Errorf("option %d: expected error, got nil", i)
+		}
+	}
+}
+
+func TestNew_baseURLWithPath(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	var paths []string
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if !strings.HasPrefix(r.URL.Path, "/api/v1/") {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"name":"pkg","versions":["1.0"]}`)
+	})
+	c, err := New(WithBaseURL(server.URL + "/api/v1/"))
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if _, err := c.GetPackage("subject", "repository", "pkg"); err != nil {
+		t.Errorf("GetPackage: unexpected error thrown %s", err)
+	}
+	if _, err := c.GetVersions("subject", "repository", "pkg"); err != nil {
+		t.Errorf("GetVersions: unexpected error thrown %s", err)
+	}
+	if _, err := c.GetRepository("subject", "repository"); err != nil {
+		t.Errorf("GetRepository: unexpected error thrown %s", err)
+	}
+	expected := []string{
+		"/api/v1/packages/subject/repository/pkg",
+		"/api/v1/packages/subject/repository/pkg",
+		"/api/v1/repos/subject/repository",
+	}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Errorf("requested %v, want %v", paths, expected)
+	}
+}
@@ -0,0 +1,227 @@
+package bintray
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+)
+
+// Package represents a Bintray package.
+// Fields set only by Bintray (ie Owner, Created, Versions) are ignored on create and update.
+// Nil PublicDownloadNumbers and PublicStats keep the Bintray value, see Bool.
+type Package struct {
+	Name                   string   `json:"name"`
+	Repo                   string   `json:"repo,omitempty"`
+	Owner                  string   `json:"owner,omitempty"`
+	Desc                   string   `json:"desc,omitempty"`
+	Labels                 []string `json:"labels,omitempty"`
+	AttributeNames         []string `json:"attribute_names,omitempty"`
+	Licenses               []string `json:"licenses,omitempty"`
+	CustomLicenses         []string `json:"custom_licenses,omitempty"`
+	FollowersCount         int      `json:"followers_count,omitempty"`
+	Created                string   `json:"created,omitempty"`
+	Updated                string   `json:"updated,omitempty"`
+	WebsiteURL             string   `json:"website_url,omitempty"`
+	IssueTrackerURL        string   `json:"issue_tracker_url,omitempty"`
+	VcsURL                 string   `json:"vcs_url,omitempty"`
+	GithubRepo             string   `json:"github_repo,omitempty"`
+	GithubReleaseNotesFile string   `json:"github_release_notes_file,omitempty"`
+	PublicDownloadNumbers  *bool    `json:"public_download_numbers,omitempty"`
+	PublicStats            *bool    `json:"public_stats,omitempty"`
+	LinkedToRepos          []string `json:"linked_to_repos,omitempty"`
+	Versions               []string `json:"versions,omitempty"`
+	LatestVersion          string   `json:"latest_version,omitempty"`
+	RatingCount            int      `json:"rating_count,omitempty"`
+	SystemIDs              []string `json:"system_ids,omitempty"`
+	Maturity               string   `json:"maturity,omitempty"`
+	// Linked is set only in ListPackages results.
+	Linked bool `json:"linked,omitempty"`
+}
+
+// ListPackagesOptions specifies the optional parameters to ListPackages.
+type ListPackagesOptions struct {
+	// StartPos is the position of the first package to return, used for pagination.
+	StartPos int
+	// StartName returns only packages with name starting with the given prefix.
+	StartName string
+}
+
+// packageWritableFields is the subset of Package fields accepted by create and update.
+type packageWritableFields struct {
+	Name                   string   `json:"name,omitempty"`
+	Desc                   string   `json:"desc,omitempty"`
+	Labels                 []string `json:"labels,omitempty"`
+	Licenses               []string `json:"licenses,omitempty"`
+	CustomLicenses         []string `json:"custom_licenses,omitempty"`
+	WebsiteURL             string   `json:"website_url,omitempty"`
+	IssueTrackerURL        string   `json:"issue_tracker_url,omitempty"`
+	VcsURL                 string   `json:"vcs_url,omitempty"`
+	GithubRepo             string   `json:"github_repo,omitempty"`
+	GithubReleaseNotesFile string   `json:"github_release_notes_file,omitempty"`
+	PublicDownloadNumbers  *bool    `json:"public_download_numbers,omitempty"`
+	PublicStats            *bool    `json:"public_stats,omitempty"`
+}
+
+func writablePackage(p *Package) *packageWritableFields {
+	return &packageWritableFields{
+		Name:                   p.Name,
+		Desc:                   p.Desc,
+		Labels:                 p.Labels,
+		Licenses:               p.Licenses,
+		CustomLicenses:         p.CustomLicenses,
+		WebsiteURL:             p.WebsiteURL,
+		IssueTrackerURL:        p.IssueTrackerURL,
+		VcsURL:                 p.VcsURL,
+		GithubRepo:             p.GithubRepo,
+		GithubReleaseNotesFile: p.GithubReleaseNotesFile,
+		PublicDownloadNumbers:  p.PublicDownloadNumbers,
+		PublicStats:            p.PublicStats,
+	}
+}
+
+// GetPackage returns the package with the given name.
+// GET /packages/:subject/:repo/:package
+func (c *Client) GetPackage(subject, repository, pkg string) (*Package, error) {
+	return c.GetPackageContext(context.Background(), subject, repository, pkg)
+}
+
+// GetPackageContext is like GetPackage but uses the given context for the request.
+func (c *Client) GetPackageContext(ctx context.Context, subject, repository, pkg string) (*Package, error) {
+	if subject == "" || repository == "" || pkg == "" {
+		return nil, errors.New("GetPackage: subject, repository and package name shouldn't be empty")
+	}
+	url := "packages/" + subject + "/" + repository + "/" + pkg
+	p := new(Package)
+	if _, err := c.executeJSON(ctx, "GET", url, nil, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// ListPackages returns the packages in the repository. Only Name and Linked are
+// set in the returned values.
+// A nil opts returns the first page of packages.
+// GET /repos/:subject/:repo/packages
+func (c *Client) ListPackages(subject, repository string, opts *ListPackagesOptions) ([]Package, error) {
+	return c.ListPackagesContext(context.Background(), subject, repository, opts)
+}
+
+// ListPackagesContext is like ListPackages but uses the given context for the request.
+func (c *Client) ListPackagesContext(ctx context.Context, subject, repository string, opts *ListPackagesOptions) ([]Package, error) {
+	if subject == "" || repository == "" {
+		return nil, errors.New("ListPackages: subject and repository shouldn't be empty")
+	}
+	u := "repos/" + subject + "/" + repository + "/packages"
+	if params := listPackagesParams(opts); len(params) > 0 {
+		u += "?" + params.Encode()
+	}
+	packages := make([]Package, 0)
+	if _, err := c.executeJSON(ctx, "GET", u, nil, &packages); err != nil {
+		return nil, err
+	}
+	return packages, nil
+}
+
+// ListPackagesIter returns an iterator over all the packages in the repository,
+// starting from opts.StartPos.
+func (c *Client) ListPackagesIter(ctx context.Context, subject, repository string, opts *ListPackagesOptions) *Iterator[Package] {
+	if subject == "" || repository == "" {
+		return errorIterator[Package](errors.New("ListPackages: subject and repository shouldn't be empty"))
+	}
+	u := "repos/" + subject + "/" + repository + "/packages"
+	return NewIterator[Package](ctx, c, u, listPackagesParams(opts))
+}
+
+func listPackagesParams(opts *ListPackagesOptions) url.Values {
+	params := url.Values{}
+	if opts != nil {
+		if opts.StartPos > 0 {
+			params.Set("start_pos", strconv.Itoa(opts.StartPos))
+		}
+		if opts.StartName != "" {
+			params.Set("start_name", opts.StartName)
+		}
+	}
+	return params
+}
+
+// CreatePackage creates a new package in the repository and returns it as stored by Bintray.
+// POST /packages/:subject/:repo
+func (c *Client) CreatePackage(subject, repository string, p *Package) (*Package, error) {
+	return c.CreatePackageContext(context.Background(), subject, repository, p)
+}
+
+// CreatePackageContext is like CreatePackage but uses the given context for the request.
+func (c *Client) CreatePackageContext(ctx context.Context, subject, repository string, p *Package) (*Package, error) {
+	if subject == "" || repository == "" || p == nil || p.Name == "" {
+		return nil, errors.New("CreatePackage: subject, repository and package name shouldn't be empty")
+	}
+	url := "packages/" + subject + "/" + repository
+	created := new(Package)
+	if _, err := c.executeJSON(ctx, "POST", url, writablePackage(p), created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// UpdatePackage updates the package named pkg with the writable fields of p.
+// Empty and nil fields are left unchanged.
+// PATCH /packages/:subject/:repo/:package
+func (c *Client) UpdatePackage(subject, repository, pkg string, p *Package) error {
+	return c.UpdatePackageContext(context.Background(), subject, repository, pkg, p)
+}
+
+// UpdatePackageContext is like UpdatePackage but uses the given context for the request.
+func (c *Client) UpdatePackageContext(ctx context.Context, subject, repository, pkg string, p *Package) error {
+	if subject == "" || repository == "" || pkg == "" || p == nil {
+		return errors.New("UpdatePackage: subject, repository, package name and package shouldn't be empty")
+	}
+	body := writablePackage(p)
+	// the name can not be changed
+	body.Name = ""
+	url := "packages/" + subject + "/" + repository + "/" + pkg
+	_, err := c.executeJSON(ctx, "PATCH", url, body, nil)
+	return err
+}
+
+// DeletePackage deletes the package and all its versions and files.
+// DELETE /packages/:subject/:repo/:package
+func (c *Client) DeletePackage(subject, repository, pkg string) error {
+	return c.DeletePackageContext(context.Background(), subject, repository, pkg)
+}
+
+// DeletePackageContext is like DeletePackage but uses the given context for the request.
+func (c *Client) DeletePackageContext(ctx context.Context, subject, repository, pkg string) error {
+	if subject == "" || repository == "" || pkg == "" {
+		return errors.New("DeletePackage: subject, repository and package name shouldn't be empty")
+	}
+	url := "packages/" + subject + "/" + repository + "/" + pkg
+	_, err := c.executeJSON(ctx, "DELETE", url, nil, nil)
+	return err
+}
+
+// LogFile describes a download log file of a package.
+type LogFile struct {
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+}
+
+// ListDownloadLogs returns the download log files of the package.
+// GET /packages/:subject/:repo/:package/logs
+func (c *Client) ListDownloadLogs(subject, repository, pkg string) ([]LogFile, error) {
+	return c.ListDownloadLogsContext(context.Background(), subject, repository, pkg)
+}
+
+// ListDownloadLogsContext is like ListDownloadLogs but uses the given context for the request.
+func (c *Client) ListDownloadLogsContext(ctx context.Context, subject, repository, pkg string) ([]LogFile, error) {
+	return c.ListDownloadLogsIter(ctx, subject, repository, pkg).All()
+}
+
+// ListDownloadLogsIter returns an iterator over the download log files of the package.
+func (c *Client) ListDownloadLogsIter(ctx context.Context, subject, repository, pkg string) *Iterator[LogFile] {
+	if subject == "" || repository == "" || pkg == "" {
+		return errorIterator[LogFile](errors.New("ListDownloadLogs: subject, repository and package name shouldn't be empty"))
+	}
+	return NewIterator[LogFile](ctx, c, "packages/"+subject+"/"+repository+"/"+pkg+"/logs", nil)
+}
@@ -0,0 +1,142 @@
+package bintray
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGetPackage(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/packages/subject/repository/pkg", func(w http.ResponseWriter, r *http.Request) {
+		if m := "GET"; m != r.Method {
+			t.Errorf("Request method = %v, want %v", r.Method, m)
+		}
+		fmt.Fprint(w, respBodyPkg)
+	})
+	p, err := client.GetPackage("subject", "repository", "pkg")
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if p.Name != "optools" || p.LatestVersion != "0.9" || len(p.Versions) != 4 {
+		t.Errorf("unexpected package %#v", p)
+	}
+}
+
+func TestGetPackage_notFound(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"Package 'pkg' was not found"}`, 404)
+	})
+	_, err := client.GetPackage("subject", "repository", "pkg")
+	if !IsNotFound(err) {
+		t.Errorf("expected not found error, got %#v", err)
+	}
+}
+
+func TestListPackages(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/repos/subject/repository/packages", func(w http.ResponseWriter, r *http.Request) {
+		if v := r.URL.Query().Get("start_pos"); v != "50" {
+			t.Errorf("start_pos = %q, want 50", v)
+		}
+		if v := r.URL.Query().Get("start_name"); v != "go-" {
+			t.Errorf("start_name = %q, want go-", v)
+		}
+		fmt.Fprint(w, `[{"name":"go-a","linked":false},{"name":"go-b","linked":true}]`)
+	})
+	packages, err := client.ListPackages("subject", "repository", &ListPackagesOptions{StartPos: 50, StartName: "go-"})
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	expected := []Package{{Name: "go-a"}, {Name: "go-b", Linked: true}}
+	if !reflect.DeepEqual(packages, expected) {
+		t.Errorf("ListPackages = %#v, want %#v", packages, expected)
+	}
+}
+
+func TestCreatePackage(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/packages/subject/repository", func(w http.ResponseWriter, r *http.Request) {
+		if m := "POST"; m != r.Method {
+			t.Errorf("Request method = %v, want %v", r.Method, m)
+		}
+		testHeader(t, r, "Content-Type", "application/json")
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["name"] != "pkg" || body["vcs_url"] != "https://github.com/enr/pkg.git" {
+			t.Errorf("unexpected request body %v", body)
+		}
+		if _, ok := body["owner"]; ok {
+			t.Errorf("read only field sent %v", body)
+		}
+		if _, ok := body["public_stats"]; ok {
+			t.Errorf("unset public_stats should not be sent: %v", body)
+		}
+		w.WriteHeader(201)
+		fmt.Fprint(w, `{"name":"pkg","repo":"repository","owner":"subject","vcs_url":"https://github.com/enr/pkg.git"}`)
+	})
+	p, err := client.CreatePackage("subject", "repository", &Package{Name: "pkg", Owner: "x", Licenses: []string{"Apache-2.0"}, VcsURL: "https://github.com/enr/pkg.git"})
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if p.Owner != "subject" {
+		t.Errorf("unexpected package %#v", p)
+	}
+}
+
+func TestCreatePackage_noName(t *testing.T) {
+	_, err := NewClient(nil, "", "").CreatePackage("subject", "repository", &Package{})
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestUpdatePackage(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/packages/subject/repository/pkg", func(w http.ResponseWriter, r *http.Request) {
+		if m := "PATCH"; m != r.Method {
+			t.Errorf("Request method = %v, want %v", r.Method, m)
+		}
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if _, ok := body["name"]; ok {
+			t.Errorf("name should not be sent on update: %v", body)
+		}
+		if body["desc"] != "new desc" {
+			t.Errorf("unexpected request body %v", body)
+		}
+		if _, ok := body["public_stats"]; ok {
+			t.Errorf("unset public_stats should not be sent on update: %v", body)
+		}
+		if body["public_download_numbers"] != false {
+			t.Errorf("public_download_numbers = %v, want false", body["public_download_numbers"])
+		}
+	})
+	err := client.UpdatePackage("subject", "repository", "pkg", &Package{Name: "pkg", Desc: "new desc", PublicDownloadNumbers: Bool(false)})
+	if err != nil {
+		t.Errorf("unexpected error thrown %s", err)
+	}
+}
+
+func TestDeletePackage(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/packages/subject/repository/pkg", func(w http.ResponseWriter, r *http.Request) {
+		if m := "DELETE"; m != r.Method {
+			t.Errorf("Request method = %v, want %v", r.Method, m)
+		}
+		fmt.Fprint(w, `{"message":"success"}`)
+	})
+	err := client.DeletePackage("subject", "repository", "pkg")
+	if err != nil {
+		t.Errorf("unexpected error thrown %s", err)
+	}
+}
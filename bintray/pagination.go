@@ -0,0 +1,113 @@
+package bintray
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// Iterator iterates over the items of a paginated list, getting the next page
+// only when the items of the current one are exhausted:
+//
+//	it := client.SearchFilesIter(ctx, &bintray.SearchFilesOptions{Name: "*.deb"})
+//	for it.Next() {
+//		f := it.Value()
+//		// ...
+//	}
+//	if err := it.Err(); err != nil {
+//		// ...
+//	}
+//
+// An Iterator is not safe for concurrent use.
+type Iterator[T any] struct {
+	ctx    context.Context
+	client *Client
+	path   string
+	params url.Values
+
+	page  []T
+	index int
+	value T
+	resp  *Response
+	done  bool
+	err   error
+}
+
+// NewIterator returns an iterator over the list at path, relative to the client
+// BaseURL, requested with the given query parameters.
+// The iteration starts at the start_pos parameter, if set, and follows the
+// pagination headers of the responses until the last page.
+func NewIterator[T any](ctx context.Context, c *Client, path string, params url.Values) *Iterator[T] {
+	query := url.Values{}
+	for k, v := range params {
+		query[k] = append([]string(nil), v...)
+	}
+	return &Iterator[T]{ctx: ctx, client: c, path: path, params: query}
+}
+
+// errorIterator returns an iterator failing with err, used for invalid arguments.
+func errorIterator[T any](err error) *Iterator[T] {
+	return &Iterator[T]{err: err, done: true}
+}
+
+// Next advances to the next item, which will then be available through Value.
+// It returns false when the iteration stops, because of the end of the list or an error.
+func (it *Iterator[T]) Next() bool {
+	for it.index >= len(it.page) {
+		if it.done || it.err != nil {
+			return false
+		}
+		it.fetch()
+	}
+	it.value = it.page[it.index]
+	it.index++
+	return true
+}
+
+// fetch gets the next page.
+func (it *Iterator[T]) fetch() {
+	u := it.path
+	if len(it.params) > 0 {
+		u += "?" + it.params.Encode()
+	}
+	page := make([]T, 0)
+	resp, err := it.client.executeJSON(it.ctx, "GET", u, nil, &page)
+	if err != nil {
+		it.err = err
+		return
+	}
+	it.page, it.index, it.resp = page, 0, resp
+	if resp.Range == nil || resp.Range.IsLast() || len(page) == 0 {
+		it.done = true
+		return
+	}
+	it.params.Set("start_pos", strconv.Itoa(resp.Range.EndPos+1))
+}
+
+// Value returns the current item.
+func (it *Iterator[T]) Value() T {
+	return it.value
+}
+
+// Err returns the error stopping the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Response returns the response of the last page got, nil before the first call to Next.
+// Its Range reports the total number of items.
+func (it *Iterator[T]) Response() *Response {
+	return it.resp
+}
+
+// All returns the remaining items, getting all the pages.
+func (it *Iterator[T]) All() ([]T, error) {
+	all := make([]T, 0)
+	for it.Next() {
+		all = append(all, it.Value())
+	}
+	if it.err != nil {
+		return nil, it.err
+	}
+	return all, nil
+}
@@ -0,0 +1,150 @@
+package bintray
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// servePages serves the given names as pages of size items, with the Bintray range headers.
+func servePages(path string, names []string, size int) *int {
+	requests := new(int)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		start, _ := strconv.Atoi(r.URL.Query().Get("start_pos"))
+		end := start + size
+		if end > len(names) {
+			end = len(names)
+		}
+		w.Header().Set("X-RangeLimit-Total", strconv.Itoa(len(names)))
+		w.Header().Set("X-RangeLimit-StartPos", strconv.Itoa(start))
+		w.Header().Set("X-RangeLimit-EndPos", strconv.Itoa(end-1))
+		fmt.Fprint(w, "[")
+		for i, name := range names[start:end] {
+			if i > 0 {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprintf(w, `{"name":%q}`, name)
+		}
+		fmt.Fprint(w, "]")
+	})
+	return requests
+}
+
+func TestIterator(t *testing.T) {
+	setup()
+	defer teardown()
+	requests := servePages("/repos/subject/repository/packages", []string{"a", "b", "c", "d", "e"}, 2)
+	it := client.ListPackagesIter(context.Background(), "subject", "repository", nil)
+	var names []string
+	for it.Next() {
+		names = append(names, it.Value().Name)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if expected := []string{"a", "b", "c", "d", "e"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("names = %v, want %v", names, expected)
+	}
+	if *requests != 3 {
+		t.Errorf("%d requests, want 3", *requests)
+	}
+	if rng := it.Response().Range; rng == nil || *rng != (Range{Total: 5, StartPos: 4, EndPos: 4}) {
+		t.Errorf("unexpected range %#v", rng)
+	}
+}
+
+func TestIterator_startPos(t *testing.T) {
+	setup()
+	defer teardown()
+	servePages("/repos/subject/repository/packages", []string{"a", "b", "c", "d", "e"}, 2)
+	packages, err := client.ListPackagesIter(context.Background(), "subject", "repository", &ListPackagesOptions{StartPos: 3}).All()
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if expected := []Package{{Name: "d"}, {Name: "e"}}; !reflect.DeepEqual(packages, expected) {
+		t.Errorf("packages = %#v, want %#v", packages, expected)
+	}
+}
+
+func TestIterator_notPaginated(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/packages/subject/repository/pkg/versions/1.2/files", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, filesResp)
+	})
+	files, err := client.GetFilesInfoIter(context.Background(), "subject", "repository", "pkg", "1.2", false).All()
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if len(files) != 1 || files[0].Sha1 != "602e20176706d3cc7535f01ffdbe91b270ae5012" {
+		t.Errorf("unexpected files %#v", files)
+	}
+}
+
+func TestIterator_error(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/packages/subject/repository/pkg/logs", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"Package 'pkg' was not found"}`, 404)
+	})
+	it := client.ListDownloadLogsIter(context.Background(), "subject", "repository", "pkg")
+	if it.Next() {
+		t.Errorf("Next should return false on error")
+	}
+	if !IsNotFound(it.Err()) {
+		t.Errorf("expected not found error, got %#v", it.Err())
+	}
+
+	it = client.ListDownloadLogsIter(context.Background(), "subject", "repository", "")
+	if it.Next() || it.Err() == nil {
+		t.Errorf("expected error for empty package name")
+	}
+}
+
+func TestListDownloadLogs(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/packages/subject/repository/pkg/logs", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"name":"pkg-2016-01-02.csv.gz","size":1234}]`)
+	})
+	logs, err := client.ListDownloadLogs("subject", "repository", "pkg")
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if expected := []LogFile{{Name: "pkg-2016-01-02.csv.gz", Size: 1234}}; !reflect.DeepEqual(logs, expected) {
+		t.Errorf("ListDownloadLogs = %#v, want %#v", logs, expected)
+	}
+}
+
+func TestSearchIter(t *testing.T) {
+	setup()
+	defer teardown()
+	names := []string{"a", "b", "c"}
+	servePages("/search/users", names, 2)
+	servePages("/search/repos", names, 2)
+	servePages("/search/packages/maven", names, 2)
+	servePages("/search/file", names, 2)
+	ctx := context.Background()
+	iterators := map[string]interface{ Next() bool }{
+		"SearchUsersIter":           client.SearchUsersIter(ctx, "enr"),
+		"SearchRepositoriesIter":    client.SearchRepositoriesIter(ctx, "jars", ""),
+		"SearchMavenPackagesIter":   client.SearchMavenPackagesIter(ctx, &SearchMavenPackagesOptions{GroupID: "org.example"}),
+		"SearchFilesByChecksumIter": client.SearchFilesByChecksumIter(ctx, sha1Of("content"), "", ""),
+	}
+	for name, it := range iterators {
+		count := 0
+		for it.Next() {
+			count++
+		}
+		if count != len(names) {
+			t.Errorf("%s: %d items, want %d", name, count, len(names))
+		}
+	}
+	if err := client.SearchUsersIter(ctx, "").Err(); err == nil {
+		t.Errorf("expected error for empty name")
+	}
+}
@@ -0,0 +1,111 @@
+package bintray
+
+import (
+	"io"
+	"time"
+)
+
+// progressInterval is the minimum interval between two progress notifications.
+const progressInterval = 200 * time.Millisecond
+
+// Progress describes the state of a transfer.
+type Progress struct {
+	// Transferred is the number of bytes sent or received so far.
+	Transferred int64
+	// Total is the size of the transfer, zero if unknown.
+	Total int64
+	// Rate is the average transfer rate in bytes per second.
+	Rate float64
+	// ETA is the estimated time to completion, zero if Total is unknown.
+	ETA time.Duration
+	// Done is true in the last notification of a completed transfer.
+	Done bool
+}
+
+// A ProgressReporter receives notifications about the progress of a transfer.
+// Notifications are sent at most every 200 milliseconds, plus a final one with Done set.
+type ProgressReporter interface {
+	Progress(p Progress)
+}
+
+// ProgressFunc is an adapter to allow the use of ordinary functions as ProgressReporter.
+type ProgressFunc func(p Progress)
+
+// Progress calls f(p).
+func (f ProgressFunc) Progress(p Progress) {
+	f(p)
+}
+
+// progressReader notifies a ProgressReporter while reading.
+type progressReader struct {
+	r        io.Reader
+	reporter ProgressReporter
+	total    int64
+	read     int64
+	// base is the position when the transfer (re)started, excluded from the rate
+	base  int64
+	start time.Time
+	last  time.Time
+	done  bool
+}
+
+// progressReadSeeker is a progressReader keeping the reader seekable, so that
+// uploads can be retried.
+type progressReadSeeker struct {
+	*progressReader
+}
+
+// newProgressReader wraps r to report progress, starting from offset bytes
+// already transferred. If reporter is nil, r is returned.
+func newProgressReader(r io.Reader, offset, total int64, reporter ProgressReporter) io.Reader {
+	if reporter == nil {
+		return r
+	}
+	pr := &progressReader{r: r, reporter: reporter, total: total, read: offset, base: offset}
+	if _, ok := r.(io.Seeker); ok {
+		return &progressReadSeeker{pr}
+	}
+	return pr
+}
+
+func (pr *progressReader) Read(p []byte) (int, error) {
+	now := time.Now()
+	if pr.start.IsZero() {
+		pr.start = now
+		pr.last = now
+	}
+	n, err := pr.r.Read(p)
+	pr.read += int64(n)
+	if !pr.done && (err == io.EOF || (pr.total > 0 && pr.read >= pr.total)) {
+		pr.done = true
+		pr.reporter.Progress(pr.progress(time.Now(), true))
+	} else if !pr.done && now.Sub(pr.last) >= progressInterval {
+		pr.last = now
+		pr.reporter.Progress(pr.progress(now, false))
+	}
+	return n, err
+}
+
+func (pr *progressReader) progress(now time.Time, done bool) Progress {
+	p := Progress{Transferred: pr.read, Total: pr.total, Done: done}
+	elapsed := now.Sub(pr.start).Seconds()
+	if elapsed > 0 {
+		p.Rate = float64(pr.read-pr.base) / elapsed
+	}
+	if pr.total > 0 && p.Rate > 0 && pr.read < pr.total {
+		p.ETA = time.Duration(float64(pr.total-pr.read) / p.Rate * float64(time.Second))
+	}
+	return p
+}
+
+// Seek seeks the underlying reader, restarting the progress count from the new offset.
+func (prs *progressReadSeeker) Seek(offset int64, whence int) (int64, error) {
+	pos, err := prs.r.(io.Seeker).Seek(offset, whence)
+	if err == nil {
+		prs.read = pos
+		prs.base = pos
+		prs.start = time.Time{}
+		prs.done = false
+	}
+	return pos, err
+}
@@ -0,0 +1,104 @@
+package bintray
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUpload_progress(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		w.WriteHeader(201)
+	})
+	var notifications []Progress
+	content := strings.Repeat("x", 4096)
+	_, err := client.Upload(context.Background(), &UploadRequest{
+		Subject: "subject", Repository: "repository", Package: "pkg", Version: "1.2",
+		Path: "file.txt",
+		Body: strings.NewReader(content),
+		Progress: ProgressFunc(func(p Progress) {
+			notifications = append(notifications, p)
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if len(notifications) == 0 {
+		t.Fatalf("no progress notified")
+	}
+	last := notifications[len(notifications)-1]
+	if !last.Done || last.Transferred != 4096 || last.Total != 4096 {
+		t.Errorf("unexpected last progress %#v", last)
+	}
+}
+
+func TestDownloadToPath_progress(t *testing.T) {
+	dir := setupDownload(t)
+	defer teardown()
+	defer os.RemoveAll(dir)
+	dest := filepath.Join(dir, "file.txt")
+	ioutil.WriteFile(dest, []byte(downloadContent[:10]), 0644)
+	var last Progress
+	err := client.DownloadToPath("subject", "repository", "a/file.txt", dest, &DownloadOptions{
+		Progress: ProgressFunc(func(p Progress) {
+			last = p
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	total := int64(len(downloadContent))
+	if !last.Done || last.Transferred != total || last.Total != total {
+		t.Errorf("unexpected last progress %#v", last)
+	}
+}
+
+func TestUploadFileWithOptions_progress(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		w.WriteHeader(201)
+	})
+	fi, _ := os.Stat("testdata/01.txt")
+	var last Progress
+	err := client.UploadFileWithOptions("subject", "repository", "pkg", "1.2", "", "", "testdata/01.txt", "", false, &UploadFileOptions{
+		Progress: ProgressFunc(func(p Progress) {
+			last = p
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if !last.Done || last.Transferred != fi.Size() || last.Total != fi.Size() {
+		t.Errorf("unexpected last progress %#v", last)
+	}
+}
+
+func TestDownloadFileWithOptions_progress(t *testing.T) {
+	dir := setupDownload(t)
+	defer teardown()
+	defer os.RemoveAll(dir)
+	var buf bytes.Buffer
+	var last Progress
+	err := client.DownloadFileWithOptions("subject", "repository", "a/file.txt", &buf, &DownloadOptions{
+		Progress: ProgressFunc(func(p Progress) {
+			last = p
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	total := int64(len(downloadContent))
+	if buf.String() != downloadContent || !last.Done || last.Transferred != total || last.Total != total {
+		t.Errorf("unexpected last progress %#v", last)
+	}
+}
@@ -0,0 +1,82 @@
+// Package progressbar provides a terminal progress bar reporting the progress
+// of Bintray uploads and downloads.
+//
+//	bar := progressbar.New(os.Stderr)
+//	err := client.DownloadToPath("subject", "repository", "file.zip", "file.zip", &bintray.DownloadOptions{
+//		Progress: bar,
+//	})
+package progressbar
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/enr/go-bintray/bintray"
+)
+
+const defaultWidth = 30
+
+// Bar is a bintray.ProgressReporter drawing a single line progress bar.
+type Bar struct {
+	// Width is the number of characters of the bar.
+	Width int
+
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// New returns a Bar writing to w, usually os.Stderr.
+func New(w io.Writer) *Bar {
+	return &Bar{w: w, Width: defaultWidth}
+}
+
+// Progress redraws the bar. A newline is written when the transfer is done.
+func (b *Bar) Progress(p bintray.Progress) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	fmt.Fprint(b.w, "\r"+Format(p, b.Width))
+	if p.Done {
+		fmt.Fprintln(b.w)
+	}
+}
+
+// Format renders the progress as a line like:
+//
+//	[=========>          ]  45% 12.3 MiB/27.0 MiB 1.2 MiB/s ETA 12s
+//
+// If the total size is unknown, only the transferred bytes and rate are shown.
+func Format(p bintray.Progress, width int) string {
+	if p.Total <= 0 {
+		return fmt.Sprintf("%s %s/s", formatBytes(p.Transferred), formatBytes(int64(p.Rate)))
+	}
+	ratio := float64(p.Transferred) / float64(p.Total)
+	if ratio > 1 {
+		ratio = 1
+	}
+	filled := int(ratio * float64(width))
+	bar := strings.Repeat("=", filled)
+	if filled < width {
+		bar += ">" + strings.Repeat(" ", width-filled-1)
+	}
+	line := fmt.Sprintf("[%s] %3d%% %s/%s %s/s", bar, int(ratio*100), formatBytes(p.Transferred), formatBytes(p.Total), formatBytes(int64(p.Rate)))
+	if !p.Done && p.ETA > 0 {
+		line += " ETA " + p.ETA.Round(time.Second).String()
+	}
+	return line
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,37 @@
+package progressbar
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/enr/go-bintray/bintray"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		p        bintray.Progress
+		expected string
+	}{
+		{bintray.Progress{Transferred: 512, Total: 1024, Rate: 256, ETA: 2 * time.Second}, "[=====>    ]  50% 512 B/1.0 KiB 256 B/s ETA 2s"},
+		{bintray.Progress{Transferred: 1024, Total: 1024, Rate: 2048, Done: true}, "[==========] 100% 1.0 KiB/1.0 KiB 2.0 KiB/s"},
+		{bintray.Progress{Transferred: 3 * 1024 * 1024, Rate: 1024}, "3.0 MiB 1.0 KiB/s"},
+	}
+	for _, tt := range tests {
+		if actual := Format(tt.p, 10); actual != tt.expected {
+			t.Errorf("Format(%#v) = %q, want %q", tt.p, actual, tt.expected)
+		}
+	}
+}
+
+func TestBar(t *testing.T) {
+	var buf bytes.Buffer
+	bar := New(&buf)
+	bar.Progress(bintray.Progress{Transferred: 10, Total: 100})
+	bar.Progress(bintray.Progress{Transferred: 100, Total: 100, Done: true})
+	out := buf.String()
+	if strings.Count(out, "\r") != 2 || !strings.HasSuffix(out, "\n") {
+		t.Errorf("unexpected output %q", out)
+	}
+}
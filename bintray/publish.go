@@ -0,0 +1,55 @@
+package bintray
+
+import (
+	"context"
+	"errors"
+)
+
+// PublishOptions specifies the optional parameters to PublishWithOptions.
+type PublishOptions struct {
+	// Discard drops all the unpublished files of the version instead of publishing them.
+	Discard bool `json:"discard,omitempty"`
+
+	// WaitForSecs makes Bintray wait up to the given seconds for the publish
+	// to complete before answering; -1 waits for the default timeout.
+	WaitForSecs int `json:"publish_wait_for_secs,omitempty"`
+
+	// Sign, if not nil, signs the version files with the GPG key stored in
+	// Bintray before publishing them.
+	Sign *SignOptions `json:"-"`
+}
+
+// PublishResult reports the outcome of a publish.
+type PublishResult struct {
+	// Files is the number of files published, or discarded.
+	Files int `json:"files"`
+}
+
+// PublishWithOptions publishes, or discards, all the unpublished files of a version.
+// A nil opts publishes the files without waiting.
+// POST /content/:subject/:repo/:package/:version/publish
+func (c *Client) PublishWithOptions(subject, repository, pkg, version string, opts *PublishOptions) (*PublishResult, error) {
+	return c.PublishWithOptionsContext(context.Background(), subject, repository, pkg, version, opts)
+}
+
+// PublishWithOptionsContext is like PublishWithOptions but uses the given context for the request.
+func (c *Client) PublishWithOptionsContext(ctx context.Context, subject, repository, pkg, version string, opts *PublishOptions) (*PublishResult, error) {
+	if subject == "" || repository == "" || pkg == "" || version == "" {
+		return nil, errors.New("Publish: subject, repository, package name and version shouldn't be empty")
+	}
+	url := "content/" + subject + "/" + repository + "/" + pkg + "/" + version + "/publish"
+	var body interface{}
+	if opts != nil {
+		body = opts
+		if opts.Sign != nil && !opts.Discard {
+			if err := c.SignVersionContext(ctx, subject, repository, pkg, version, opts.Sign); err != nil {
+				return nil, err
+			}
+		}
+	}
+	result := new(PublishResult)
+	if _, err := c.executeJSON(ctx, "POST", url, body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,64 @@
+package bintray
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestPublishWithOptions(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/content/subject/repository/pkg/1.2/publish", func(w http.ResponseWriter, r *http.Request) {
+		if m := "POST"; m != r.Method {
+			t.Errorf("Request method = %v, want %v", r.Method, m)
+		}
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["publish_wait_for_secs"] != float64(-1) {
+			t.Errorf("unexpected request body %v", body)
+		}
+		if _, ok := body["discard"]; ok {
+			t.Errorf("unexpected discard in request body %v", body)
+		}
+		fmt.Fprint(w, `{"files":39}`)
+	})
+	result, err := client.PublishWithOptions("subject", "repository", "pkg", "1.2", &PublishOptions{WaitForSecs: -1})
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if result.Files != 39 {
+		t.Errorf("PublishResult.Files = %d, want 39", result.Files)
+	}
+}
+
+func TestPublishWithOptions_discard(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/content/subject/repository/pkg/1.2/publish", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["discard"] != true {
+			t.Errorf("unexpected request body %v", body)
+		}
+		fmt.Fprint(w, `{"files":3}`)
+	})
+	result, err := client.PublishWithOptions("subject", "repository", "pkg", "1.2", &PublishOptions{Discard: true})
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if result.Files != 3 {
+		t.Errorf("PublishResult.Files = %d, want 3", result.Files)
+	}
+}
+
+func TestPublish_transportError(t *testing.T) {
+	setup()
+	teardown()
+	// the server is closed: the call must fail without panic
+	err := client.Publish("subject", "repository", "pkg", "1.2")
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
@@ -0,0 +1,185 @@
+package bintray
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Repository types supported by Bintray.
+const (
+	RepositoryTypeGeneric = "generic"
+	RepositoryTypeMaven   = "maven"
+	RepositoryTypeDebian  = "debian"
+	RepositoryTypeRpm     = "rpm"
+	RepositoryTypeNpm     = "npm"
+	RepositoryTypeDocker  = "docker"
+	RepositoryTypeNuget   = "nuget"
+	RepositoryTypeOpkg    = "opkg"
+	RepositoryTypeVagrant = "vagrant"
+	RepositoryTypeConan   = "conan"
+)
+
+var repositoryTypes = []string{
+	RepositoryTypeGeneric,
+	RepositoryTypeMaven,
+	RepositoryTypeDebian,
+	RepositoryTypeRpm,
+	RepositoryTypeNpm,
+	RepositoryTypeDocker,
+	RepositoryTypeNuget,
+	RepositoryTypeOpkg,
+	RepositoryTypeVagrant,
+	RepositoryTypeConan,
+}
+
+// Repository represents a Bintray repository.
+type Repository struct {
+	Name            string   `json:"name"`
+	Owner           string   `json:"owner,omitempty"`
+	Type            string   `json:"type,omitempty"`
+	Private         bool     `json:"private"`
+	Premium         bool     `json:"premium"`
+	Desc            string   `json:"desc,omitempty"`
+	Labels          []string `json:"labels,omitempty"`
+	Created         string   `json:"created,omitempty"`
+	PackageCount    int      `json:"package_count,omitempty"`
+	GpgSignMetadata bool     `json:"gpg_sign_metadata"`
+	GpgSignFiles    bool     `json:"gpg_sign_files"`
+	GpgUseOwnerKey  bool     `json:"gpg_use_owner_key"`
+}
+
+// UpdateRepositoryOptions are the values accepted updating a repository.
+// Empty and nil fields are left unchanged, see Bool.
+type UpdateRepositoryOptions struct {
+	Desc            string   `json:"desc,omitempty"`
+	Labels          []string `json:"labels,omitempty"`
+	GpgSignMetadata *bool    `json:"gpg_sign_metadata,omitempty"`
+	GpgSignFiles    *bool    `json:"gpg_sign_files,omitempty"`
+	GpgUseOwnerKey  *bool    `json:"gpg_use_owner_key,omitempty"`
+}
+
+// ListRepositories returns the repositories owned by the subject.
+// Only Name and Owner are set in the returned values.
+// GET /repos/:subject
+func (c *Client) ListRepositories(subject string) ([]Repository, error) {
+	return c.ListRepositoriesContext(context.Background(), subject)
+}
+
+// ListRepositoriesContext is like ListRepositories but uses the given context for the request.
+func (c *Client) ListRepositoriesContext(ctx context.Context, subject string) ([]Repository, error) {
+	if subject == "" {
+		return nil, errors.New("ListRepositories: subject shouldn't be empty")
+	}
+	repositories := make([]Repository, 0)
+	if _, err := c.executeJSON(ctx, "GET", "repos/"+subject, nil, &repositories); err != nil {
+		return nil, err
+	}
+	return repositories, nil
+}
+
+// GetRepository returns the given repository.
+// GET /repos/:subject/:repo
+func (c *Client) GetRepository(subject, repository string) (*Repository, error) {
+	return c.GetRepositoryContext(context.Background(), subject, repository)
+}
+
+// GetRepositoryContext is like GetRepository but uses the given context for the request.
+func (c *Client) GetRepositoryContext(ctx context.Context, subject, repository string) (*Repository, error) {
+	if subject == "" || repository == "" {
+		return nil, errors.New("GetRepository: subject and repository shouldn't be empty")
+	}
+	r := new(Repository)
+	if _, err := c.executeJSON(ctx, "GET", "repos/"+subject+"/"+repository, nil, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// CreateRepository creates the repository r.Name and returns it as stored by Bintray.
+// r.Type must be one of the RepositoryType constants.
+// POST /repos/:subject/:repo
+func (c *Client) CreateRepository(subject string, r *Repository) (*Repository, error) {
+	return c.CreateRepositoryContext(context.Background(), subject, r)
+}
+
+// CreateRepositoryContext is like CreateRepository but uses the given context for the request.
+func (c *Client) CreateRepositoryContext(ctx context.Context, subject string, r *Repository) (*Repository, error) {
+	if subject == "" || r == nil || r.Name == "" {
+		return nil, errors.New("CreateRepository: subject and repository name shouldn't be empty")
+	}
+	if !validRepositoryType(r.Type) {
+		return nil, fmt.Errorf("CreateRepository: invalid repository type %q", r.Type)
+	}
+	body := *r
+	// read only fields
+	body.Owner = ""
+	body.Created = ""
+	body.PackageCount = 0
+	created := new(Repository)
+	if _, err := c.executeJSON(ctx, "POST", "repos/"+subject+"/"+r.Name, &body, created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// UpdateRepository updates the given repository.
+// PATCH /repos/:subject/:repo
+func (c *Client) UpdateRepository(subject, repository string, opts *UpdateRepositoryOptions) error {
+	return c.UpdateRepositoryContext(context.Background(), subject, repository, opts)
+}
+
+// UpdateRepositoryContext is like UpdateRepository but uses the given context for the request.
+func (c *Client) UpdateRepositoryContext(ctx context.Context, subject, repository string, opts *UpdateRepositoryOptions) error {
+	if subject == "" || repository == "" || opts == nil {
+		return errors.New("UpdateRepository: subject, repository and options shouldn't be empty")
+	}
+	_, err := c.executeJSON(ctx, "PATCH", "repos/"+subject+"/"+repository, opts, nil)
+	return err
+}
+
+// DeleteRepository deletes the given repository with all its packages.
+// DELETE /repos/:subject/:repo
+func (c *Client) DeleteRepository(subject, repository string) error {
+	return c.DeleteRepositoryContext(context.Background(), subject, repository)
+}
+
+// DeleteRepositoryContext is like DeleteRepository but uses the given context for the request.
+func (c *Client) DeleteRepositoryContext(ctx context.Context, subject, repository string) error {
+	if subject == "" || repository == "" {
+		return errors.New("DeleteRepository: subject and repository shouldn't be empty")
+	}
+	_, err := c.executeJSON(ctx, "DELETE", "repos/"+subject+"/"+repository, nil, nil)
+	return err
+}
+
+// CalcMetadata schedules the calculation of the repository metadata, ie the
+// Debian indexes or the YUM repodata. Path, if not empty, restricts the
+// calculation to a directory of the repository.
+// POST /calc_metadata/:subject/:repo/[:path]
+func (c *Client) CalcMetadata(subject, repository, path string) error {
+	return c.CalcMetadataContext(context.Background(), subject, repository, path)
+}
+
+// CalcMetadataContext is like CalcMetadata but uses the given context for the request.
+func (c *Client) CalcMetadataContext(ctx context.Context, subject, repository, path string) error {
+	if subject == "" || repository == "" {
+		return errors.New("CalcMetadata: subject and repository shouldn't be empty")
+	}
+	url := "calc_metadata/" + subject + "/" + repository
+	if path = strings.Trim(path, "/"); path != "" {
+		url += "/" + path
+	}
+	_, err := c.executeJSON(ctx, "POST", url, nil, nil)
+	return err
+}
+
+func validRepositoryType(t string) bool {
+	for _, rt := range repositoryTypes {
+		if t == rt {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package bintray
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestListRepositories(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/repos/subject", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"name":"repo1","owner":"subject"},{"name":"repo2","owner":"subject"}]`)
+	})
+	repositories, err := client.ListRepositories("subject")
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if len(repositories) != 2 || repositories[1].Name != "repo2" {
+		t.Errorf("unexpected repositories %#v", repositories)
+	}
+}
+
+func TestGetRepository(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/repos/subject/repo", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"repo","owner":"subject","type":"maven","private":false,"premium":false,
+"desc":"This repo...","labels":["label1"],"created":"2013-03-04T09:50:00.742Z","package_count":3,
+"gpg_sign_metadata":true,"gpg_sign_files":false,"gpg_use_owner_key":false}`)
+	})
+	r, err := client.GetRepository("subject", "repo")
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if r.Type != RepositoryTypeMaven || r.PackageCount != 3 || !r.GpgSignMetadata {
+		t.Errorf("unexpected repository %#v", r)
+	}
+}
+
+func TestCreateRepository(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/repos/subject/repo", func(w http.ResponseWriter, r *http.Request) {
+		if m := "POST"; m != r.Method {
+			t.Errorf("Request method = %v, want %v", r.Method, m)
+		}
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["type"] != "debian" || body["private"] != true {
+			t.Errorf("unexpected request body %v", body)
+		}
+		if _, ok := body["owner"]; ok {
+			t.Errorf("read only field sent %v", body)
+		}
+		w.WriteHeader(201)
+		fmt.Fprint(w, `{"name":"repo","owner":"subject","type":"debian","private":true}`)
+	})
+	r, err := client.CreateRepository("subject", &Repository{Name: "repo", Owner: "x", Type: RepositoryTypeDebian, Private: true})
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if r.Owner != "subject" {
+		t.Errorf("unexpected repository %#v", r)
+	}
+}
+
+func TestCreateRepository_invalidType(t *testing.T) {
+	_, err := NewClient(nil, "", "").CreateRepository("subject", &Repository{Name: "repo", Type: "cargo"})
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestUpdateRepository(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/repos/subject/repo", func(w http.ResponseWriter, r *http.Request) {
+		if m := "PATCH"; m != r.Method {
+			t.Errorf("Request method = %v, want %v", r.Method, m)
+		}
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["desc"] != "new desc" || body["gpg_sign_files"] != true {
+			t.Errorf("unexpected request body %v", body)
+		}
+		if _, ok := body["gpg_sign_metadata"]; ok {
+			t.Errorf("unset gpg_sign_metadata should not be sent: %v", body)
+		}
+	})
+	err := client.UpdateRepository("subject", "repo", &UpdateRepositoryOptions{Desc: "new desc", GpgSignFiles: Bool(true)})
+	if err != nil {
+		t.Errorf("unexpected error thrown %s", err)
+	}
+}
+
+func TestDeleteRepository(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/repos/subject/repo", func(w http.ResponseWriter, r *http.Request) {
+		if m := "DELETE"; m != r.Method {
+			t.Errorf("Request method = %v, want %v", r.Method, m)
+		}
+		fmt.Fprint(w, `{"message":"success"}`)
+	})
+	err := client.DeleteRepository("subject", "repo")
+	if err != nil {
+		t.Errorf("unexpected error thrown %s", err)
+	}
+}
@@ -3,18 +3,106 @@ package bintray
 import (
 	"io/ioutil"
 	"net/http"
+	"strconv"
+	"time"
 )
 
+const (
+	headerRateLimit     = "X-RateLimit-Limit"
+	headerRateRemaining = "X-RateLimit-Remaining"
+	headerRateReset     = "X-RateLimit-Reset"
+
+	headerRangeTotal    = "X-RangeLimit-Total"
+	headerRangeStartPos = "X-RangeLimit-StartPos"
+	headerRangeEndPos   = "X-RangeLimit-EndPos"
+)
+
+// DefaultRateLimitWindow is the wait before sending requests again when the rate
+// limit is exhausted and Bintray doesn't tell when it resets.
+const DefaultRateLimitWindow = time.Minute
+
+// Rate represents the rate limit for the current client.
+type Rate struct {
+	// The number of requests per window the client is allowed to make.
+	Limit int
+
+	// The number of remaining requests the client can make in the current window.
+	Remaining int
+
+	// The time at which the current window will reset, from X-RateLimit-Reset
+	// or Retry-After. Zero if Bintray did not send them.
+	Reset time.Time
+}
+
+// Range is the position of a page in a paginated list.
+type Range struct {
+	// Total is the number of items in the list.
+	Total int
+
+	// StartPos is the position of the first item in the page.
+	StartPos int
+
+	// EndPos is the position of the last item in the page.
+	EndPos int
+}
+
+// IsLast reports whether the page is the last one.
+func (r Range) IsLast() bool {
+	return r.EndPos+1 >= r.Total
+}
+
 // Response wraps the Bintray API response.
 type Response struct {
 	*http.Response
+
+	// Rate limit parsed from the response headers.
+	// Zero if the response has no rate limit headers.
+	Rate Rate
+
+	// Range of the returned page, nil if the response is not paginated.
+	Range *Range
 }
 
 func newResponse(r *http.Response) *Response {
 	response := &Response{Response: r}
+	response.Rate = parseRate(r)
+	response.Range = parseRange(r)
 	return response
 }
 
+// parseRate parses the rate related headers.
+func parseRate(r *http.Response) Rate {
+	var rate Rate
+	if limit := r.Header.Get(headerRateLimit); limit != "" {
+		rate.Limit, _ = strconv.Atoi(limit)
+	}
+	if remaining := r.Header.Get(headerRateRemaining); remaining != "" {
+		rate.Remaining, _ = strconv.Atoi(remaining)
+	}
+	if reset := r.Header.Get(headerRateReset); reset != "" {
+		if v, err := strconv.ParseInt(reset, 10, 64); err == nil && v > 0 {
+			rate.Reset = time.Unix(v, 0)
+		}
+	} else if d, ok := parseRetryAfter(r.Header.Get("Retry-After")); ok {
+		rate.Reset = time.Now().Add(d)
+	}
+	return rate
+}
+
+// parseRange parses the pagination headers.
+func parseRange(r *http.Response) *Range {
+	total, err := strconv.Atoi(r.Header.Get(headerRangeTotal))
+	if err != nil {
+		return nil
+	}
+	rng := &Range{Total: total}
+	rng.StartPos, _ = strconv.Atoi(r.Header.Get(headerRangeStartPos))
+	if rng.EndPos, err = strconv.Atoi(r.Header.Get(headerRangeEndPos)); err != nil {
+		rng.EndPos = total - 1
+	}
+	return rng
+}
+
 // BodyAsString returns the response body as string.
 func (r *Response) BodyAsString() (string, error) {
 	body, err := r.readAndCloseResponseBody()
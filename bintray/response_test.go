@@ -0,0 +1,117 @@
+package bintray
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestResponse_rate(t *testing.T) {
+	setup()
+	defer teardown()
+	reset := time.Now().Add(time.Hour).Unix()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerRateLimit, "300")
+		w.Header().Set(headerRateRemaining, "299")
+		w.Header().Set(headerRateReset, strconv.FormatInt(reset, 10))
+		fmt.Fprint(w, "ok")
+	})
+	req, _ := client.newRequestWithBody(context.Background(), "GET", "/", "")
+	response, err := client.execute(req)
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	expected := Rate{Limit: 300, Remaining: 299, Reset: time.Unix(reset, 0)}
+	if response.Rate != expected {
+		t.Errorf("Response.Rate = %#v, want %#v", response.Rate, expected)
+	}
+	if client.Rate() != expected {
+		t.Errorf("Client.Rate() = %#v, want %#v", client.Rate(), expected)
+	}
+}
+
+func TestResponse_noRate(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	})
+	req, _ := client.newRequestWithBody(context.Background(), "GET", "/", "")
+	response, _ := client.execute(req)
+	if response.Rate != (Rate{}) {
+		t.Errorf("expected zero Rate, got %#v", response.Rate)
+	}
+}
+
+func TestWaitOnRateLimit(t *testing.T) {
+	setup()
+	defer teardown()
+	client.WaitOnRateLimit = true
+	reset := time.Now().Add(time.Hour)
+	client.rate = Rate{Limit: 300, Remaining: 0, Reset: reset}
+	called := false
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		fmt.Fprint(w, "ok")
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := client.GetVersionsContext(ctx, "subject", "repository", "pkg")
+	if err != context.DeadlineExceeded {
+		t.Errorf("expected context.DeadlineExceeded, got %#v", err)
+	}
+	if called {
+		t.Errorf("request should not be sent before the rate limit reset")
+	}
+
+	client.rate = Rate{Limit: 300, Remaining: 0, Reset: time.Now().Add(-time.Second)}
+	client.GetVersions("subject", "repository", "pkg")
+	if !called {
+		t.Errorf("request should be sent after the rate limit reset")
+	}
+}
+
+func TestWaitOnRateLimit_noReset(t *testing.T) {
+	setup()
+	defer teardown()
+	client.WaitOnRateLimit = true
+	client.RateLimitWindow = 100 * time.Millisecond
+	var sent []time.Time
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		sent = append(sent, time.Now())
+		w.Header().Set(headerRateLimit, "300")
+		w.Header().Set(headerRateRemaining, "0")
+		fmt.Fprint(w, "ok")
+	})
+	for i := 0; i < 2; i++ {
+		req, _ := client.newRequestWithBody(context.Background(), "GET", "", "")
+		if _, err := client.execute(req); err != nil {
+			t.Fatalf("unexpected error thrown %s", err)
+		}
+	}
+	if len(sent) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(sent))
+	}
+	if d := sent[1].Sub(sent[0]); d < client.RateLimitWindow {
+		t.Errorf("second request sent after %v, want at least %v", d, client.RateLimitWindow)
+	}
+}
+
+func TestResponse_rateRetryAfter(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerRateLimit, "300")
+		w.Header().Set(headerRateRemaining, "0")
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	req, _ := client.newRequestWithBody(context.Background(), "GET", "", "")
+	response, _ := client.execute(req)
+	if d := time.Until(response.Rate.Reset); d <= 28*time.Second || d > 30*time.Second {
+		t.Errorf("Rate.Reset in %v, want 30s from Retry-After", d)
+	}
+}
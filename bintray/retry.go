@@ -0,0 +1,148 @@
+package bintray
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how the Client retries failed requests.
+// Only idempotent requests (GET, HEAD, PUT, DELETE, OPTIONS) whose body can be
+// sent again are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	// Values lower than 2 disable retries.
+	MaxAttempts int
+
+	// MinBackoff is the wait before the first retry; it doubles at every
+	// following attempt, up to MaxBackoff. Zero MaxBackoff means no limit.
+	// The Retry-After sent with 429 and 503 responses is not limited.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// Jitter randomizes the backoff in the [backoff/2, backoff) range to
+	// avoid retrying clients to hit the server at the same time.
+	Jitter bool
+
+	// RetryableStatus lists the response status codes to retry on.
+	// Transport errors are always retried.
+	RetryableStatus []int
+}
+
+// DefaultRetryPolicy returns a policy retrying 3 times on transport errors,
+// 429 and 5xx gateway errors, with a backoff starting at 1 second.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 4,
+		MinBackoff:  1 * time.Second,
+		MaxBackoff:  30 * time.Second,
+		Jitter:      true,
+		RetryableStatus: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// shouldRetry reports if the request can be sent again after the given attempt.
+func (p *RetryPolicy) shouldRetry(req *http.Request, attempt int, resp *http.Response, err error) bool {
+	if p == nil || attempt >= p.MaxAttempts {
+		return false
+	}
+	if req.Context().Err() != nil {
+		return false
+	}
+	if !isIdempotent(req.Method) {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	for _, s := range p.RetryableStatus {
+		if resp.StatusCode == s {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the wait before the next attempt.
+// Retry-After is honored for 429 and 503 responses, even over MaxBackoff:
+// retrying earlier would be rejected again. The wait ends anyway when the
+// request context is done.
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return d
+		}
+	}
+	d := p.MinBackoff
+	for i := 1; i < attempt && (p.MaxBackoff == 0 || d < p.MaxBackoff); i++ {
+		if d > math.MaxInt64/2 {
+			// uncapped backoff overflowing
+			break
+		}
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if p.Jitter && d > 1 {
+		d = d/2 + time.Duration(rand.Int63n(int64(d/2)))
+	}
+	return d
+}
+
+// parseRetryAfter parses the Retry-After header, either in seconds or HTTP-date format.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "PUT", "DELETE", "OPTIONS":
+		return true
+	}
+	return false
+}
+
+// sleepContext waits for the given duration, returning early with the context error
+// if the context is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
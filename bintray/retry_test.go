@@ -0,0 +1,196 @@
+package bintray
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func testRetryPolicy() *RetryPolicy {
+	p := DefaultRetryPolicy()
+	p.MinBackoff = time.Millisecond
+	p.MaxBackoff = 5 * time.Millisecond
+	return p
+}
+
+func TestRetry_serverError(t *testing.T) {
+	setup()
+	defer teardown()
+	client.RetryPolicy = testRetryPolicy()
+	calls := 0
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			http.Error(w, "", http.StatusBadGateway)
+			return
+		}
+		fmt.Fprint(w, respBodyPkg)
+	})
+	versions, err := client.GetVersions("subject", "repository", "pkg")
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if len(versions) != 4 {
+		t.Errorf("expected 4 versions, got %d", len(versions))
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+}
+
+func TestRetry_maxAttempts(t *testing.T) {
+	setup()
+	defer teardown()
+	client.RetryPolicy = testRetryPolicy()
+	calls := 0
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.Error(w, "", http.StatusServiceUnavailable)
+	})
+	_, err := client.GetVersions("subject", "repository", "pkg")
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+	if calls != client.RetryPolicy.MaxAttempts {
+		t.Errorf("expected %d calls, got %d", client.RetryPolicy.MaxAttempts, calls)
+	}
+}
+
+func TestRetry_notIdempotent(t *testing.T) {
+	setup()
+	defer teardown()
+	client.RetryPolicy = testRetryPolicy()
+	calls := 0
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.Error(w, "", http.StatusServiceUnavailable)
+	})
+	err := client.CreateVersion("subject", "repository", "pkg", "0.1.2")
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+	if calls != 1 {
+		t.Errorf("POST should not be retried, got %d calls", calls)
+	}
+}
+
+func TestRetry_uploadResendsBody(t *testing.T) {
+	setup()
+	defer teardown()
+	client.RetryPolicy = testRetryPolicy()
+	calls := 0
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) != "Hello 01!\n" {
+			t.Errorf("attempt %d: unexpected body %q", calls, body)
+		}
+		if calls == 1 {
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprint(w, `{"message":"success"}`)
+	})
+	err := client.UploadFile("subject", "repository", "pkg", "1.2", "", "", "testdata/01.txt", "", false)
+	if err != nil {
+		t.Errorf("unexpected error thrown %s", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+}
+
+func TestRetry_retryAfterOverMaxBackoff(t *testing.T) {
+	setup()
+	defer teardown()
+	client.RetryPolicy = testRetryPolicy()
+	calls := 0
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "60")
+		http.Error(w, "", http.StatusServiceUnavailable)
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := client.GetVersionsContext(ctx, "subject", "repository", "pkg")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context deadline error, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 call waiting for Retry-After, got %d", calls)
+	}
+}
+
+func TestRetry_disabled(t *testing.T) {
+	setup()
+	defer teardown()
+	calls := 0
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.Error(w, "", http.StatusServiceUnavailable)
+	})
+	client.GetVersions("subject", "repository", "pkg")
+	if calls != 1 {
+		t.Errorf("expected 1 call without retry policy, got %d", calls)
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := &RetryPolicy{MinBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, e := range expected {
+		if d := p.backoff(i+1, nil); d != e {
+			t.Errorf("backoff(%d) = %v, want %v", i+1, d, e)
+		}
+	}
+	p.Jitter = true
+	for i := 1; i < 5; i++ {
+		if d := p.backoff(3, nil); d < 2*time.Second || d >= 4*time.Second {
+			t.Errorf("backoff with jitter out of range: %v", d)
+		}
+	}
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	resp.Header.Set("Retry-After", "7")
+	if d := p.backoff(1, resp); d != 7*time.Second {
+		t.Errorf("backoff with Retry-After over MaxBackoff = %v, want 7s", d)
+	}
+	resp.Header.Set("Retry-After", "3")
+	if d := p.backoff(1, resp); d != 3*time.Second {
+		t.Errorf("backoff with Retry-After = %v, want 3s", d)
+	}
+}
+
+func TestRetryPolicy_backoffUncapped(t *testing.T) {
+	p := &RetryPolicy{MinBackoff: time.Second}
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second}
+	for i, e := range expected {
+		if d := p.backoff(i+1, nil); d != e {
+			t.Errorf("backoff(%d) = %v, want %v", i+1, d, e)
+		}
+	}
+	if d := p.backoff(100, nil); d <= 0 {
+		t.Errorf("backoff(100) overflowed: %v", d)
+	}
+	resp := &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}}
+	resp.Header.Set("Retry-After", "120")
+	if d := p.backoff(1, resp); d != 120*time.Second {
+		t.Errorf("backoff with Retry-After = %v, want 120s", d)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if d, ok := parseRetryAfter("120"); !ok || d != 120*time.Second {
+		t.Errorf("parseRetryAfter(120) = %v, %v", d, ok)
+	}
+	date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if d, ok := parseRetryAfter(date); !ok || d <= 58*time.Minute {
+		t.Errorf("parseRetryAfter(%s) = %v, %v", date, d, ok)
+	}
+	if _, ok := parseRetryAfter("soon"); ok {
+		t.Errorf("parseRetryAfter(soon) should fail")
+	}
+}
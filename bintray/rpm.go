@@ -0,0 +1,296 @@
+package bintray
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+const (
+	rpmLeadLength        = 96
+	rpmIndexEntryLength  = 16
+	rpmHeaderIntroLength = 16
+	// rpmMaxHeaderLength guards against corrupted headers, the limit used by rpm itself
+	rpmMaxHeaderLength = 256 << 20
+
+	rpmTagName      = 1000
+	rpmTagVersion   = 1001
+	rpmTagRelease   = 1002
+	rpmTagEpoch     = 1003
+	rpmTagSummary   = 1004
+	rpmTagArch      = 1022
+	rpmTagSourceRPM = 1044
+
+	rpmTypeInt32       = 4
+	rpmTypeString      = 6
+	rpmTypeStringArray = 8
+	rpmTypeI18NString  = 9
+
+	rpmSourcePackage = 1
+)
+
+var (
+	rpmLeadMagic   = []byte{0xed, 0xab, 0xee, 0xdb}
+	rpmHeaderMagic = []byte{0x8e, 0xad, 0xe8}
+)
+
+// RpmHeader is the metadata of an RPM package.
+type RpmHeader struct {
+	Name    string
+	Version string
+	Release string
+	// Epoch is zero if the package has no epoch.
+	Epoch int
+	// Arch is `src` for source packages.
+	Arch    string
+	Summary string
+	// SourceRPM is the name of the source package, empty for source packages.
+	SourceRPM string
+}
+
+// FileName returns the conventional file name of the package, ie `hello-2.10-1.el7.x86_64.rpm`.
+func (h *RpmHeader) FileName() string {
+	return h.Name + "-" + h.Version + "-" + h.Release + "." + h.Arch + ".rpm"
+}
+
+// Path returns the conventional path of the package in a YUM repository,
+// in a directory by architecture, ie `x86_64/hello-2.10-1.el7.x86_64.rpm`.
+func (h *RpmHeader) Path() string {
+	return h.Arch + "/" + h.FileName()
+}
+
+// ReadRpmHeader reads the lead and the headers of an RPM package, up to the payload.
+func ReadRpmHeader(rpm io.Reader) (*RpmHeader, error) {
+	lead := make([]byte, rpmLeadLength)
+	if _, err := io.ReadFull(rpm, lead); err != nil || !bytes.Equal(lead[:4], rpmLeadMagic) {
+		return nil, errors.New("ReadRpmHeader: not an RPM package")
+	}
+	source := binary.BigEndian.Uint16(lead[6:8]) == rpmSourcePackage
+
+	// the signature header is padded to a multiple of 8 bytes
+	if _, _, err := readRpmHeaderStructure(rpm, true); err != nil {
+		return nil, err
+	}
+	index, store, err := readRpmHeaderStructure(rpm, false)
+	if err != nil {
+		return nil, err
+	}
+	h := &RpmHeader{}
+	for _, e := range index {
+		switch e.tag {
+		case rpmTagName:
+			h.Name, err = e.str(store)
+		case rpmTagVersion:
+			h.Version, err = e.str(store)
+		case rpmTagRelease:
+			h.Release, err = e.str(store)
+		case rpmTagArch:
+			h.Arch, err = e.str(store)
+		case rpmTagSummary:
+			h.Summary, err = e.str(store)
+		case rpmTagSourceRPM:
+			h.SourceRPM, err = e.str(store)
+		case rpmTagEpoch:
+			h.Epoch, err = e.int32(store)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if source {
+		h.Arch = "src"
+	}
+	if h.Name == "" || h.Version == "" || h.Release == "" || h.Arch == "" {
+		return nil, errors.New("ReadRpmHeader: name, version, release and arch shouldn't be empty")
+	}
+	return h, nil
+}
+
+// rpmIndexEntry describes a tag value in the header store.
+type rpmIndexEntry struct {
+	tag, typ, offset, count uint32
+}
+
+// readRpmHeaderStructure reads a header structure returning its index entries and data store.
+func readRpmHeaderStructure(r io.Reader, padded bool) ([]rpmIndexEntry, []byte, error) {
+	intro := make([]byte, rpmHeaderIntroLength)
+	if _, err := io.ReadFull(r, intro); err != nil {
+		return nil, nil, fmt.Errorf("ReadRpmHeader: %v", err)
+	}
+	if !bytes.Equal(intro[:3], rpmHeaderMagic) {
+		return nil, nil, errors.New("ReadRpmHeader: invalid header magic")
+	}
+	count := binary.BigEndian.Uint32(intro[8:12])
+	size := binary.BigEndian.Uint32(intro[12:16])
+	if uint64(count)*rpmIndexEntryLength+uint64(size) > rpmMaxHeaderLength {
+		return nil, nil, errors.New("ReadRpmHeader: header too large")
+	}
+	data := make([]byte, int(count)*rpmIndexEntryLength+int(size))
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, nil, fmt.Errorf("ReadRpmHeader: %v", err)
+	}
+	if padded && size%8 != 0 {
+		if _, err := io.CopyN(ioutil.Discard, r, int64(8-size%8)); err != nil {
+			return nil, nil, fmt.Errorf("ReadRpmHeader: %v", err)
+		}
+	}
+	index := make([]rpmIndexEntry, count)
+	for i := range index {
+		e := data[i*rpmIndexEntryLength:]
+		index[i] = rpmIndexEntry{
+			tag:    binary.BigEndian.Uint32(e[0:4]),
+			typ:    binary.BigEndian.Uint32(e[4:8]),
+			offset: binary.BigEndian.Uint32(e[8:12]),
+			count:  binary.BigEndian.Uint32(e[12:16]),
+		}
+	}
+	return index, data[int(count)*rpmIndexEntryLength:], nil
+}
+
+// str returns the first string of a string entry.
+func (e rpmIndexEntry) str(store []byte) (string, error) {
+	switch e.typ {
+	case rpmTypeString, rpmTypeStringArray, rpmTypeI18NString:
+	default:
+		return "", fmt.Errorf("ReadRpmHeader: tag %d is not a string", e.tag)
+	}
+	if int64(e.offset) >= int64(len(store)) {
+		return "", fmt.Errorf("ReadRpmHeader: tag %d out of the header", e.tag)
+	}
+	value := store[e.offset:]
+	if end := bytes.IndexByte(value, 0); end >= 0 {
+		value = value[:end]
+	}
+	return string(value), nil
+}
+
+func (e rpmIndexEntry) int32(store []byte) (int, error) {
+	if e.typ != rpmTypeInt32 {
+		return 0, fmt.Errorf("ReadRpmHeader: tag %d is not an integer", e.tag)
+	}
+	if int64(e.offset)+4 > int64(len(store)) {
+		return 0, fmt.Errorf("ReadRpmHeader: tag %d out of the header", e.tag)
+	}
+	return int(int32(binary.BigEndian.Uint32(store[e.offset:]))), nil
+}
+
+// RpmUploadRequest describes the upload of an RPM package.
+type RpmUploadRequest struct {
+	Subject    string
+	Repository string
+
+	// Package and Version are the Bintray package and version, created if missing.
+	// If empty, the RPM name and `version-release` are used.
+	Package string
+	Version string
+
+	// Path is the remote path of the file. If empty, RpmHeader.Path is used.
+	Path string
+
+	// Body is the content of the RPM: it is read to get the header, then
+	// rewound and uploaded.
+	Body io.ReadSeeker
+
+	// NewPackage holds the fields used creating the Bintray package, ie Licenses
+	// and VcsURL, mandatory for open source repositories. The name is ignored.
+	NewPackage *Package
+
+	// UploadOptions are the options of the upload.
+	UploadOptions
+
+	// CalcMetadata schedules the calculation of the YUM metadata after the upload.
+	CalcMetadata bool
+
+	// Progress is the UploadRequest progress reporter.
+	Progress ProgressReporter
+}
+
+// RpmUploadResult reports the outcome of an RPM upload.
+type RpmUploadResult struct {
+	Header *RpmHeader
+	Upload *UploadResult
+}
+
+// UploadRpm uploads an RPM package, creating the Bintray package and version if missing.
+func (c *Client) UploadRpm(ctx context.Context, r *RpmUploadRequest) (*RpmUploadResult, error) {
+	if r == nil || r.Subject == "" || r.Repository == "" || r.Body == nil {
+		return nil, errors.New("UploadRpm: subject, repository and body shouldn't be empty")
+	}
+	start, err := r.Body.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+	header, err := ReadRpmHeader(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	end, err := r.Body.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.Body.Seek(start, io.SeekStart); err != nil {
+		return nil, err
+	}
+	pkg, version, remotePath := r.Package, r.Version, r.Path
+	if pkg == "" {
+		pkg = header.Name
+	}
+	if version == "" {
+		version = header.Version + "-" + header.Release
+	}
+	if remotePath == "" {
+		remotePath = header.Path()
+	}
+	result := &RpmUploadResult{Header: header}
+	if err := c.ensureVersion(ctx, r.Subject, r.Repository, pkg, version, r.NewPackage); err != nil {
+		return result, err
+	}
+	result.Upload, err = c.Upload(ctx, &UploadRequest{
+		Subject:    r.Subject,
+		Repository: r.Repository,
+		Package:    pkg,
+		Version:    version,
+		Path:       remotePath,
+		Body:       r.Body,
+		Length:     end - start,
+		Options:    r.UploadOptions,
+		Progress:   r.Progress,
+	})
+	if err != nil {
+		return result, err
+	}
+	if r.CalcMetadata {
+		err = c.CalcMetadataContext(ctx, r.Subject, r.Repository, "")
+	}
+	return result, err
+}
+
+// ensureVersion creates the package and the version if they don't exist.
+// The package is created from newPackage, if not nil.
+func (c *Client) ensureVersion(ctx context.Context, subject, repository, pkg, version string, newPackage *Package) error {
+	_, err := c.GetPackageContext(ctx, subject, repository, pkg)
+	if IsNotFound(err) {
+		p := Package{}
+		if newPackage != nil {
+			p = *newPackage
+		}
+		p.Name = pkg
+		_, err = c.CreatePackageContext(ctx, subject, repository, &p)
+	}
+	// a conflict means that someone else just created it
+	if err != nil && !IsConflict(err) {
+		return err
+	}
+	_, err = c.GetVersionContext(ctx, subject, repository, pkg, version)
+	if IsNotFound(err) {
+		_, err = c.CreateVersionWithOptionsContext(ctx, subject, repository, pkg, &CreateVersionOptions{Name: version})
+	}
+	if err != nil && !IsConflict(err) {
+		return err
+	}
+	return nil
+}
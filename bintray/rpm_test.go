@@ -0,0 +1,225 @@
+package bintray
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+// rpmTag is a header entry used building test packages.
+type rpmTag struct {
+	tag   uint32
+	value interface{}
+}
+
+// buildRpmHeaderStructure returns a header structure holding the given tags,
+// padded to 8 bytes if requested as the signature header is.
+func buildRpmHeaderStructure(tags []rpmTag, padded bool) []byte {
+	var index, store bytes.Buffer
+	for _, t := range tags {
+		var typ uint32
+		switch v := t.value.(type) {
+		case string:
+			typ = rpmTypeString
+			binary.Write(&index, binary.BigEndian, []uint32{t.tag, typ, uint32(store.Len()), 1})
+			store.WriteString(v)
+			store.WriteByte(0)
+		case int32:
+			for store.Len()%4 != 0 {
+				store.WriteByte(0)
+			}
+			typ = rpmTypeInt32
+			binary.Write(&index, binary.BigEndian, []uint32{t.tag, typ, uint32(store.Len()), 1})
+			binary.Write(&store, binary.BigEndian, v)
+		}
+	}
+	var h bytes.Buffer
+	h.Write(rpmHeaderMagic)
+	h.Write([]byte{1, 0, 0, 0, 0})
+	binary.Write(&h, binary.BigEndian, []uint32{uint32(len(tags)), uint32(store.Len())})
+	h.Write(index.Bytes())
+	h.Write(store.Bytes())
+	if padded {
+		for store.Len()%8 != 0 {
+			store.WriteByte(0)
+			h.WriteByte(0)
+		}
+	}
+	return h.Bytes()
+}
+
+// buildRpm returns a minimal RPM package with the given header tags.
+func buildRpm(tags []rpmTag, source bool) []byte {
+	lead := make([]byte, rpmLeadLength)
+	copy(lead, rpmLeadMagic)
+	lead[4] = 3
+	if source {
+		lead[7] = rpmSourcePackage
+	}
+	var rpm bytes.Buffer
+	rpm.Write(lead)
+	rpm.Write(buildRpmHeaderStructure([]rpmTag{{1000, "size"}}, true))
+	rpm.Write(buildRpmHeaderStructure(tags, false))
+	rpm.WriteString("payload")
+	return rpm.Bytes()
+}
+
+var helloRpmTags = []rpmTag{
+	{rpmTagName, "hello"},
+	{rpmTagVersion, "2.10"},
+	{rpmTagRelease, "1.el7"},
+	{rpmTagEpoch, int32(1)},
+	{rpmTagSummary, "The hello package"},
+	{rpmTagArch, "x86_64"},
+	{rpmTagSourceRPM, "hello-2.10-1.el7.src.rpm"},
+}
+
+func TestReadRpmHeader(t *testing.T) {
+	h, err := ReadRpmHeader(bytes.NewReader(buildRpm(helloRpmTags, false)))
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	expected := RpmHeader{
+		Name:      "hello",
+		Version:   "2.10",
+		Release:   "1.el7",
+		Epoch:     1,
+		Arch:      "x86_64",
+		Summary:   "The hello package",
+		SourceRPM: "hello-2.10-1.el7.src.rpm",
+	}
+	if *h != expected {
+		t.Errorf("ReadRpmHeader = %#v, want %#v", h, expected)
+	}
+	if p := h.Path(); p != "x86_64/hello-2.10-1.el7.x86_64.rpm" {
+		t.Errorf("Path = %q", p)
+	}
+}
+
+func TestReadRpmHeader_source(t *testing.T) {
+	h, err := ReadRpmHeader(bytes.NewReader(buildRpm(helloRpmTags[:3], true)))
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if h.Arch != "src" || h.FileName() != "hello-2.10-1.el7.src.rpm" {
+		t.Errorf("unexpected source header %#v", h)
+	}
+}
+
+func TestReadRpmHeader_invalid(t *testing.T) {
+	if _, err := ReadRpmHeader(bytes.NewReader([]byte("not an rpm"))); err == nil {
+		t.Errorf("expected error reading an invalid package")
+	}
+	if _, err := ReadRpmHeader(bytes.NewReader(buildRpm(helloRpmTags[:2], false))); err == nil {
+		t.Errorf("expected error for header without release")
+	}
+	rpm := buildRpm(helloRpmTags, false)
+	if _, err := ReadRpmHeader(bytes.NewReader(rpm[:len(rpm)/2])); err == nil {
+		t.Errorf("expected error reading a truncated package")
+	}
+}
+
+func TestUploadRpm(t *testing.T) {
+	setup()
+	defer teardown()
+	rpm := buildRpm(helloRpmTags, false)
+	created := map[string]string{}
+	mux.HandleFunc("/packages/subject/rpm/hello", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+		fmt.Fprint(w, `{"message":"Package 'hello' was not found"}`)
+	})
+	mux.HandleFunc("/packages/subject/rpm", func(w http.ResponseWriter, r *http.Request) {
+		if m := "POST"; m != r.Method {
+			t.Errorf("Request method = %v, want %v", r.Method, m)
+		}
+		p := new(Package)
+		json.NewDecoder(r.Body).Decode(p)
+		created["package"] = p.Name + " " + p.VcsURL
+		w.WriteHeader(201)
+		fmt.Fprint(w, `{"name":"hello"}`)
+	})
+	mux.HandleFunc("/packages/subject/rpm/hello/versions/2.10-1.el7", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+		fmt.Fprint(w, `{"message":"Version '2.10-1.el7' was not found"}`)
+	})
+	mux.HandleFunc("/packages/subject/rpm/hello/versions", func(w http.ResponseWriter, r *http.Request) {
+		if m := "POST"; m != r.Method {
+			t.Errorf("Request method = %v, want %v", r.Method, m)
+		}
+		v := new(CreateVersionOptions)
+		json.NewDecoder(r.Body).Decode(v)
+		created["version"] = v.Name
+		w.WriteHeader(201)
+		fmt.Fprint(w, `{"name":"2.10-1.el7"}`)
+	})
+	mux.HandleFunc("/content/subject/rpm/hello/2.10-1.el7/x86_64/hello-2.10-1.el7.x86_64.rpm", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if !bytes.Equal(body, rpm) {
+			t.Errorf("uploaded %d bytes, want the whole package (%d bytes)", len(body), len(rpm))
+		}
+		w.WriteHeader(201)
+	})
+	calculated := false
+	mux.HandleFunc("/calc_metadata/subject/rpm", func(w http.ResponseWriter, r *http.Request) {
+		calculated = true
+		w.WriteHeader(202)
+	})
+	result, err := client.UploadRpm(context.Background(), &RpmUploadRequest{
+		Subject:      "subject",
+		Repository:   "rpm",
+		Body:         bytes.NewReader(rpm),
+		NewPackage:   &Package{VcsURL: "https://example.com/hello.git"},
+		CalcMetadata: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if result.Header.Name != "hello" || result.Upload.Path != "x86_64/hello-2.10-1.el7.x86_64.rpm" {
+		t.Errorf("unexpected result %#v", result)
+	}
+	if created["package"] != "hello https://example.com/hello.git" || created["version"] != "2.10-1.el7" {
+		t.Errorf("unexpected creations %v", created)
+	}
+	if !calculated {
+		t.Errorf("metadata calculation not requested")
+	}
+}
+
+func TestUploadRpm_existingVersion(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/packages/subject/rpm/hello", func(w http.ResponseWriter, r *http.Request) {
+		if m := "GET"; m != r.Method {
+			t.Errorf("Request method = %v, want %v", r.Method, m)
+		}
+		fmt.Fprint(w, `{"name":"hello"}`)
+	})
+	mux.HandleFunc("/packages/subject/rpm/hello/versions/2.10", func(w http.ResponseWriter, r *http.Request) {
+		if m := "GET"; m != r.Method {
+			t.Errorf("Request method = %v, want %v", r.Method, m)
+		}
+		fmt.Fprint(w, `{"name":"2.10"}`)
+	})
+	mux.HandleFunc("/content/subject/rpm/hello/2.10/el7/hello.rpm", func(w http.ResponseWriter, r *http.Request) {
+		if p := r.URL.Query().Get("publish"); p != "1" {
+			t.Errorf("publish = %q, want 1", p)
+		}
+		w.WriteHeader(201)
+	})
+	_, err := client.UploadRpm(context.Background(), &RpmUploadRequest{
+		Subject:       "subject",
+		Repository:    "rpm",
+		Version:       "2.10",
+		Path:          "el7/hello.rpm",
+		Body:          bytes.NewReader(buildRpm(helloRpmTags, false)),
+		UploadOptions: UploadOptions{Publish: true},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+}
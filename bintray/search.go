@@ -0,0 +1,231 @@
+package bintray
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+)
+
+// User represents a Bintray user, as returned by SearchUsers.
+type User struct {
+	Name           string   `json:"name"`
+	FullName       string   `json:"full_name,omitempty"`
+	GravatarID     string   `json:"gravatar_id,omitempty"`
+	Repos          []string `json:"repos,omitempty"`
+	Organizations  []string `json:"organizations,omitempty"`
+	FollowersCount int      `json:"followers_count,omitempty"`
+	Registered     string   `json:"registered,omitempty"`
+	QuotaUsedBytes int64    `json:"quota_used_bytes,omitempty"`
+}
+
+// SearchPackagesOptions are the criteria of SearchPackages.
+// At least one of Name and Desc must be set.
+type SearchPackagesOptions struct {
+	Name    string
+	Desc    string
+	Subject string
+	Repo    string
+}
+
+// SearchFilesOptions are the criteria of SearchFiles.
+// Name is mandatory and accepts the `*` and `?` wildcards.
+type SearchFilesOptions struct {
+	Name    string
+	Subject string
+	Repo    string
+	// CreatedAfter returns only the files created after the given time, if not zero.
+	CreatedAfter time.Time
+}
+
+// SearchMavenPackagesOptions are the criteria of SearchMavenPackages.
+// At least one of GroupID, ArtifactID and Query must be set.
+type SearchMavenPackagesOptions struct {
+	GroupID    string
+	ArtifactID string
+	// Query is a `groupId:artifactId` search, accepting the `*` and `?` wildcards.
+	Query   string
+	Subject string
+	Repo    string
+}
+
+// AttributeQuery is the body of an attributes search: each element maps an
+// attribute name to the values it must match. Use AttributeSearch to build it.
+type AttributeQuery []map[string]interface{}
+
+// SearchPackages returns the packages matching the given criteria.
+// GET /search/packages
+func (c *Client) SearchPackages(opts *SearchPackagesOptions) ([]Package, error) {
+	return c.SearchPackagesContext(context.Background(), opts)
+}
+
+// SearchPackagesContext is like SearchPackages but uses the given context for the request.
+func (c *Client) SearchPackagesContext(ctx context.Context, opts *SearchPackagesOptions) ([]Package, error) {
+	return c.SearchPackagesIter(ctx, opts).All()
+}
+
+// SearchPackagesIter returns an iterator over the packages matching the given criteria,
+// getting a page at a time.
+func (c *Client) SearchPackagesIter(ctx context.Context, opts *SearchPackagesOptions) *Iterator[Package] {
+	if opts == nil || (opts.Name == "" && opts.Desc == "") {
+		return errorIterator[Package](errors.New("SearchPackages: name or description shouldn't be empty"))
+	}
+	params := searchParams("name", opts.Name, "desc", opts.Desc, "subject", opts.Subject, "repo", opts.Repo)
+	return NewIterator[Package](ctx, c, "search/packages", params)
+}
+
+// SearchFiles returns the files matching the given criteria.
+// GET /search/file
+func (c *Client) SearchFiles(opts *SearchFilesOptions) ([]FileData, error) {
+	return c.SearchFilesContext(context.Background(), opts)
+}
+
+// SearchFilesContext is like SearchFiles but uses the given context for the request.
+func (c *Client) SearchFilesContext(ctx context.Context, opts *SearchFilesOptions) ([]FileData, error) {
+	return c.SearchFilesIter(ctx, opts).All()
+}
+
+// SearchFilesIter returns an iterator over the files matching the given criteria,
+// getting a page at a time.
+func (c *Client) SearchFilesIter(ctx context.Context, opts *SearchFilesOptions) *Iterator[FileData] {
+	if opts == nil || opts.Name == "" {
+		return errorIterator[FileData](errors.New("SearchFiles: name shouldn't be empty"))
+	}
+	params := searchParams("name", opts.Name, "subject", opts.Subject, "repo", opts.Repo)
+	if !opts.CreatedAfter.IsZero() {
+		params.Set("created_after", opts.CreatedAfter.UTC().Format(timeFormat))
+	}
+	return NewIterator[FileData](ctx, c, "search/file", params)
+}
+
+// SearchFilesByChecksum returns the files with the given SHA1.
+// Subject and repository are optional and narrow the search.
+// GET /search/file?sha1=:sha1
+func (c *Client) SearchFilesByChecksum(sha1, subject, repository string) ([]FileData, error) {
+	return c.SearchFilesByChecksumContext(context.Background(), sha1, subject, repository)
+}
+
+// SearchFilesByChecksumContext is like SearchFilesByChecksum but uses the given context for the request.
+func (c *Client) SearchFilesByChecksumContext(ctx context.Context, sha1, subject, repository string) ([]FileData, error) {
+	return c.SearchFilesByChecksumIter(ctx, sha1, subject, repository).All()
+}
+
+// SearchFilesByChecksumIter returns an iterator over the files with the given SHA1,
+// getting a page at a time.
+func (c *Client) SearchFilesByChecksumIter(ctx context.Context, sha1, subject, repository string) *Iterator[FileData] {
+	if sha1 == "" {
+		return errorIterator[FileData](errors.New("SearchFilesByChecksum: sha1 shouldn't be empty"))
+	}
+	params := searchParams("sha1", sha1, "subject", subject, "repo", repository)
+	return NewIterator[FileData](ctx, c, "search/file", params)
+}
+
+// SearchUsers returns the users with name matching the given one.
+// GET /search/users
+func (c *Client) SearchUsers(name string) ([]User, error) {
+	return c.SearchUsersContext(context.Background(), name)
+}
+
+// SearchUsersContext is like SearchUsers but uses the given context for the request.
+func (c *Client) SearchUsersContext(ctx context.Context, name string) ([]User, error) {
+	return c.SearchUsersIter(ctx, name).All()
+}
+
+// SearchUsersIter returns an iterator over the users with name matching the given one,
+// getting a page at a time.
+func (c *Client) SearchUsersIter(ctx context.Context, name string) *Iterator[User] {
+	if name == "" {
+		return errorIterator[User](errors.New("SearchUsers: name shouldn't be empty"))
+	}
+	return NewIterator[User](ctx, c, "search/users", searchParams("name", name))
+}
+
+// SearchMavenPackages returns the packages containing files with the given Maven coordinates.
+// GET /search/packages/maven
+func (c *Client) SearchMavenPackages(opts *SearchMavenPackagesOptions) ([]Package, error) {
+	return c.SearchMavenPackagesContext(context.Background(), opts)
+}
+
+// SearchMavenPackagesContext is like SearchMavenPackages but uses the given context for the request.
+func (c *Client) SearchMavenPackagesContext(ctx context.Context, opts *SearchMavenPackagesOptions) ([]Package, error) {
+	return c.SearchMavenPackagesIter(ctx, opts).All()
+}
+
+// SearchMavenPackagesIter returns an iterator over the packages containing files
+// with the given Maven coordinates, getting a page at a time.
+func (c *Client) SearchMavenPackagesIter(ctx context.Context, opts *SearchMavenPackagesOptions) *Iterator[Package] {
+	if opts == nil || (opts.GroupID == "" && opts.ArtifactID == "" && opts.Query == "") {
+		return errorIterator[Package](errors.New("SearchMavenPackages: group id, artifact id or query shouldn't be empty"))
+	}
+	params := searchParams("g", opts.GroupID, "a", opts.ArtifactID, "q", opts.Query, "subject", opts.Subject, "repo", opts.Repo)
+	return NewIterator[Package](ctx, c, "search/packages/maven", params)
+}
+
+// SearchRepositories returns the repositories with name or description matching the given ones.
+// Only Name and Owner are set in the returned values.
+// GET /search/repos
+func (c *Client) SearchRepositories(name, desc string) ([]Repository, error) {
+	return c.SearchRepositoriesContext(context.Background(), name, desc)
+}
+
+// SearchRepositoriesContext is like SearchRepositories but uses the given context for the request.
+func (c *Client) SearchRepositoriesContext(ctx context.Context, name, desc string) ([]Repository, error) {
+	return c.SearchRepositoriesIter(ctx, name, desc).All()
+}
+
+// SearchRepositoriesIter returns an iterator over the repositories with name or
+// description matching the given ones, getting a page at a time.
+func (c *Client) SearchRepositoriesIter(ctx context.Context, name, desc string) *Iterator[Repository] {
+	if name == "" && desc == "" {
+		return errorIterator[Repository](errors.New("SearchRepositories: name or description shouldn't be empty"))
+	}
+	return NewIterator[Repository](ctx, c, "search/repos", searchParams("name", name, "desc", desc))
+}
+
+// SearchPackagesByAttributes returns the packages of the repository matching the attributes query.
+// POST /search/attributes/:subject/:repo
+func (c *Client) SearchPackagesByAttributes(subject, repository string, query AttributeQuery) ([]Package, error) {
+	return c.SearchPackagesByAttributesContext(context.Background(), subject, repository, query)
+}
+
+// SearchPackagesByAttributesContext is like SearchPackagesByAttributes but uses the given context for the request.
+func (c *Client) SearchPackagesByAttributesContext(ctx context.Context, subject, repository string, query AttributeQuery) ([]Package, error) {
+	if subject == "" || repository == "" || len(query) == 0 {
+		return nil, errors.New("SearchPackagesByAttributes: subject, repository and query shouldn't be empty")
+	}
+	packages := make([]Package, 0)
+	if _, err := c.executeJSON(ctx, "POST", "search/attributes/"+subject+"/"+repository, query, &packages); err != nil {
+		return nil, err
+	}
+	return packages, nil
+}
+
+// SearchVersionsByAttributes returns the versions of the package matching the attributes query.
+// POST /search/attributes/:subject/:repo/:package/versions
+func (c *Client) SearchVersionsByAttributes(subject, repository, pkg string, query AttributeQuery) ([]Version, error) {
+	return c.SearchVersionsByAttributesContext(context.Background(), subject, repository, pkg, query)
+}
+
+// SearchVersionsByAttributesContext is like SearchVersionsByAttributes but uses the given context for the request.
+func (c *Client) SearchVersionsByAttributesContext(ctx context.Context, subject, repository, pkg string, query AttributeQuery) ([]Version, error) {
+	if subject == "" || repository == "" || pkg == "" || len(query) == 0 {
+		return nil, errors.New("SearchVersionsByAttributes: subject, repository, package name and query shouldn't be empty")
+	}
+	url := "search/attributes/" + subject + "/" + repository + "/" + pkg + "/versions"
+	versions := make([]Version, 0)
+	if _, err := c.executeJSON(ctx, "POST", url, query, &versions); err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// searchParams builds the query parameters from name and value pairs, skipping empty values.
+func searchParams(pairs ...string) url.Values {
+	params := url.Values{}
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] != "" {
+			params.Set(pairs[i], pairs[i+1])
+		}
+	}
+	return params
+}
@@ -0,0 +1,203 @@
+package bintray
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSearchPackages_pages(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/search/packages", func(w http.ResponseWriter, r *http.Request) {
+		if v := r.URL.Query().Get("name"); v != "go" {
+			t.Errorf("name = %q, want go", v)
+		}
+		if v := r.URL.Query().Get("subject"); v != "subject" {
+			t.Errorf("subject = %q, want subject", v)
+		}
+		start, _ := strconv.Atoi(r.URL.Query().Get("start_pos"))
+		w.Header().Set("X-RangeLimit-Total", "3")
+		w.Header().Set("X-RangeLimit-StartPos", strconv.Itoa(start))
+		switch start {
+		case 0:
+			w.Header().Set("X-RangeLimit-EndPos", "1")
+			fmt.Fprint(w, `[{"name":"go-a"},{"name":"go-b"}]`)
+		case 2:
+			w.Header().Set("X-RangeLimit-EndPos", "2")
+			fmt.Fprint(w, `[{"name":"go-c"}]`)
+		default:
+			t.Errorf("unexpected start_pos %d", start)
+		}
+	})
+	packages, err := client.SearchPackages(&SearchPackagesOptions{Name: "go", Subject: "subject"})
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	expected := []Package{{Name: "go-a"}, {Name: "go-b"}, {Name: "go-c"}}
+	if !reflect.DeepEqual(packages, expected) {
+		t.Errorf("SearchPackages = %#v, want %#v", packages, expected)
+	}
+}
+
+func TestSearchFiles(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/search/file", func(w http.ResponseWriter, r *http.Request) {
+		if v := r.URL.Query().Get("name"); v != "*.jar" {
+			t.Errorf("name = %q, want *.jar", v)
+		}
+		if v := r.URL.Query().Get("created_after"); v != "2016-01-02T03:04:05.000Z" {
+			t.Errorf("created_after = %q", v)
+		}
+		fmt.Fprint(w, filesResp)
+	})
+	files, err := client.SearchFiles(&SearchFilesOptions{
+		Name:         "*.jar",
+		CreatedAfter: time.Date(2016, 1, 2, 3, 4, 5, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if len(files) != 1 || files[0].Name != "nutcracker-1.1-sources.jar" {
+		t.Errorf("unexpected files %#v", files)
+	}
+}
+
+func TestSearchFilesByChecksum(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/search/file", func(w http.ResponseWriter, r *http.Request) {
+		if v := r.URL.Query().Get("sha1"); v != "602e20176706d3cc7535f01ffdbe91b270ae5012" {
+			t.Errorf("sha1 = %q", v)
+		}
+		if v := r.URL.Query().Get("repo"); v != "" {
+			t.Errorf("empty repo should not be sent, got %q", v)
+		}
+		fmt.Fprint(w, filesResp)
+	})
+	files, err := client.SearchFilesByChecksum("602e20176706d3cc7535f01ffdbe91b270ae5012", "", "")
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if len(files) != 1 || files[0].Sha1 != "602e20176706d3cc7535f01ffdbe91b270ae5012" {
+		t.Errorf("unexpected files %#v", files)
+	}
+}
+
+func TestSearchUsers(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/search/users", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"name":"enr","full_name":"Enrico","repos":["maven"],"followers_count":2}]`)
+	})
+	users, err := client.SearchUsers("enr")
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	expected := []User{{Name: "enr", FullName: "Enrico", Repos: []string{"maven"}, FollowersCount: 2}}
+	if !reflect.DeepEqual(users, expected) {
+		t.Errorf("SearchUsers = %#v, want %#v", users, expected)
+	}
+}
+
+func TestSearchMavenPackages(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/search/packages/maven", func(w http.ResponseWriter, r *http.Request) {
+		if q := r.URL.RawQuery; q != "a=nutcracker&g=org.jfrog.powerutils" {
+			t.Errorf("query = %q", q)
+		}
+		fmt.Fprint(w, `[{"name":"jfrog-power-utils","repo":"jfrog-jars","owner":"jfrog"}]`)
+	})
+	packages, err := client.SearchMavenPackages(&SearchMavenPackagesOptions{GroupID: "org.jfrog.powerutils", ArtifactID: "nutcracker"})
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if len(packages) != 1 || packages[0].Repo != "jfrog-jars" {
+		t.Errorf("unexpected packages %#v", packages)
+	}
+}
+
+func TestSearchRepositories(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/search/repos", func(w http.ResponseWriter, r *http.Request) {
+		if q := r.URL.RawQuery; q != "desc=jars" {
+			t.Errorf("query = %q", q)
+		}
+		fmt.Fprint(w, `[{"name":"jfrog-jars","owner":"jfrog"}]`)
+	})
+	repositories, err := client.SearchRepositories("", "jars")
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	expected := []Repository{{Name: "jfrog-jars", Owner: "jfrog"}}
+	if !reflect.DeepEqual(repositories, expected) {
+		t.Errorf("SearchRepositories = %#v, want %#v", repositories, expected)
+	}
+}
+
+func TestSearchPackagesByAttributes(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/search/attributes/subject/repository", func(w http.ResponseWriter, r *http.Request) {
+		if m := "POST"; m != r.Method {
+			t.Errorf("Request method = %v, want %v", r.Method, m)
+		}
+		var query []map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&query)
+		expected := []map[string]interface{}{{"os": []interface{}{"linux", "windows"}}}
+		if !reflect.DeepEqual(query, expected) {
+			t.Errorf("query = %#v, want %#v", query, expected)
+		}
+		fmt.Fprint(w, "["+respBodyPkg+"]")
+	})
+	mux.HandleFunc("/search/attributes/subject/repository/pkg/versions", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"name":"1.2","package":"pkg"}]`)
+	})
+	query := AttributeQuery{{"os": []string{"linux", "windows"}}}
+	packages, err := client.SearchPackagesByAttributes("subject", "repository", query)
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if len(packages) != 1 || packages[0].Name != "optools" {
+		t.Errorf("unexpected packages %#v", packages)
+	}
+	versions, err := client.SearchVersionsByAttributes("subject", "repository", "pkg", query)
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if len(versions) != 1 || versions[0].Name != "1.2" {
+		t.Errorf("unexpected versions %#v", versions)
+	}
+}
+
+func TestSearch_emptyCriteria(t *testing.T) {
+	c := NewClient(nil, "", "")
+	if _, err := c.SearchPackages(&SearchPackagesOptions{Subject: "subject"}); err == nil {
+		t.Errorf("expected error searching packages without name")
+	}
+	if _, err := c.SearchFiles(nil); err == nil {
+		t.Errorf("expected error searching files without name")
+	}
+	if _, err := c.SearchFilesByChecksum("", "subject", ""); err == nil {
+		t.Errorf("expected error searching files without sha1")
+	}
+	if _, err := c.SearchUsers(""); err == nil {
+		t.Errorf("expected error searching users without name")
+	}
+	if _, err := c.SearchMavenPackages(&SearchMavenPackagesOptions{Repo: "repo"}); err == nil {
+		t.Errorf("expected error searching maven packages without coordinates")
+	}
+	if _, err := c.SearchRepositories("", ""); err == nil {
+		t.Errorf("expected error searching repositories without name")
+	}
+	if _, err := c.SearchVersionsByAttributes("subject", "repository", "pkg", nil); err == nil {
+		t.Errorf("expected error searching versions without query")
+	}
+}
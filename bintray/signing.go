@@ -0,0 +1,134 @@
+package bintray
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+)
+
+// signatureExtension is the extension of detached ASCII armored signatures.
+const signatureExtension = ".asc"
+
+// A Signer produces detached OpenPGP signatures with a private key held locally,
+// never sent to Bintray.
+type Signer struct {
+	entity *openpgp.Entity
+}
+
+// NewSigner returns a Signer using the first private key found in the ASCII
+// armored key ring, decrypted with passphrase if protected.
+func NewSigner(armoredKeyRing io.Reader, passphrase string) (*Signer, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(armoredKeyRing)
+	if err != nil {
+		return nil, err
+	}
+	var entity *openpgp.Entity
+	for _, e := range entities {
+		if e.PrivateKey != nil {
+			entity = e
+			break
+		}
+	}
+	if entity == nil {
+		return nil, errors.New("bintray: no private key found in key ring")
+	}
+	if entity.PrivateKey.Encrypted {
+		if err := entity.PrivateKey.Decrypt([]byte(passphrase)); err != nil {
+			return nil, err
+		}
+	}
+	for _, subkey := range entity.Subkeys {
+		if subkey.PrivateKey != nil && subkey.PrivateKey.Encrypted {
+			if err := subkey.PrivateKey.Decrypt([]byte(passphrase)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &Signer{entity: entity}, nil
+}
+
+// Sign writes to w the ASCII armored detached signature of message.
+func (s *Signer) Sign(w io.Writer, message io.Reader) error {
+	return openpgp.ArmoredDetachSign(w, s.entity, message, nil)
+}
+
+// pendingSignature is a signature computed while the content is uploaded.
+type pendingSignature struct {
+	buf  bytes.Buffer
+	pw   *io.PipeWriter
+	done chan error
+	err  error
+}
+
+// signing starts signing body, returning the reader to upload in its place.
+// A seekable body is signed upfront and rewound to start, so that retries are
+// still possible; other bodies are signed while being read.
+func (s *Signer) signing(body io.Reader, start int64) (io.Reader, *pendingSignature, error) {
+	p := &pendingSignature{}
+	if seeker, ok := body.(io.Seeker); ok {
+		if err := s.Sign(&p.buf, body); err != nil {
+			return nil, nil, err
+		}
+		if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+			return nil, nil, err
+		}
+		return body, p, nil
+	}
+	pr, pw := io.Pipe()
+	p.pw = pw
+	p.done = make(chan error, 1)
+	go func() {
+		err := s.Sign(&p.buf, pr)
+		// a signing failure aborts the upload
+		pr.CloseWithError(err)
+		p.done <- err
+	}()
+	return io.TeeReader(body, pw), p, nil
+}
+
+// finish waits for the signature to complete. A non nil readErr aborts it.
+func (p *pendingSignature) finish(readErr error) ([]byte, error) {
+	if p.pw != nil {
+		p.pw.CloseWithError(readErr)
+		p.err = <-p.done
+		p.pw = nil
+	}
+	if readErr != nil {
+		return nil, readErr
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	return p.buf.Bytes(), nil
+}
+
+// DownloadAndVerify downloads a file and its `.asc` detached signature, writing
+// the file content to w and checking the signature against the ASCII armored
+// public key ring. The content is written while being verified: on error the
+// caller must discard what was written to w.
+func (c *Client) DownloadAndVerify(subject, repository, filePath string, armoredKeyRing io.Reader, w io.Writer) error {
+	return c.DownloadAndVerifyContext(context.Background(), subject, repository, filePath, armoredKeyRing, w)
+}
+
+// DownloadAndVerifyContext is like DownloadAndVerify but uses the given context for the requests.
+func (c *Client) DownloadAndVerifyContext(ctx context.Context, subject, repository, filePath string, armoredKeyRing io.Reader, w io.Writer) error {
+	keyRing, err := openpgp.ReadArmoredKeyRing(armoredKeyRing)
+	if err != nil {
+		return err
+	}
+	var signature bytes.Buffer
+	if err := c.DownloadFileContext(ctx, subject, repository, filePath+signatureExtension, &signature); err != nil {
+		return err
+	}
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(c.DownloadFileContext(ctx, subject, repository, filePath, pw))
+	}()
+	_, err = openpgp.CheckArmoredDetachedSignature(keyRing, io.TeeReader(pr, w), &signature, nil)
+	// unblock the download if the check stopped reading
+	pr.CloseWithError(err)
+	return err
+}
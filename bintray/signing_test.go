@@ -0,0 +1,120 @@
+package bintray
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+)
+
+// testKeys returns an armored private and public key ring.
+func testKeys(t *testing.T) (string, string) {
+	entity, err := openpgp.NewEntity("test", "", "test@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var private, public bytes.Buffer
+	w, _ := armor.Encode(&private, openpgp.PrivateKeyType, nil)
+	if err := entity.SerializePrivate(w, nil); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+	w, _ = armor.Encode(&public, openpgp.PublicKeyType, nil)
+	entity.Serialize(w)
+	w.Close()
+	return private.String(), public.String()
+}
+
+func TestNewSigner_noPrivateKey(t *testing.T) {
+	_, public := testKeys(t)
+	if _, err := NewSigner(strings.NewReader(public), ""); err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestUpload_signer(t *testing.T) {
+	private, public := testKeys(t)
+	signer, err := NewSigner(strings.NewReader(private), "")
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	bodies := []func() io.Reader{
+		func() io.Reader { return strings.NewReader("signed content") },
+		func() io.Reader { return io.MultiReader(strings.NewReader("signed "), strings.NewReader("content")) },
+	}
+	for _, body := range bodies {
+		setup()
+		var mu sync.Mutex
+		uploaded := map[string][]byte{}
+		mux.HandleFunc("/content/subject/repository/pkg/1.2/", func(w http.ResponseWriter, r *http.Request) {
+			data, _ := ioutil.ReadAll(r.Body)
+			mu.Lock()
+			uploaded[r.URL.Path] = data
+			mu.Unlock()
+			if strings.HasSuffix(r.URL.Path, ".asc") && r.URL.Query().Get("override") != "1" {
+				t.Errorf("signature should override the existing one")
+			}
+			w.WriteHeader(201)
+		})
+		result, err := client.Upload(context.Background(), &UploadRequest{
+			Subject: "subject", Repository: "repository", Package: "pkg", Version: "1.2",
+			Path:    "a/file.txt",
+			Body:    body(),
+			Options: UploadOptions{Signer: signer},
+		})
+		teardown()
+		if err != nil {
+			t.Fatalf("unexpected error thrown %s", err)
+		}
+		if result.SignaturePath != "a/file.txt.asc" {
+			t.Errorf("SignaturePath = %q", result.SignaturePath)
+		}
+		content := uploaded["/content/subject/repository/pkg/1.2/a/file.txt"]
+		signature := uploaded["/content/subject/repository/pkg/1.2/a/file.txt.asc"]
+		if string(content) != "signed content" {
+			t.Errorf("unexpected content %q", content)
+		}
+		keyRing, _ := openpgp.ReadArmoredKeyRing(strings.NewReader(public))
+		if _, err := openpgp.CheckArmoredDetachedSignature(keyRing, bytes.NewReader(content), bytes.NewReader(signature), nil); err != nil {
+			t.Errorf("invalid signature: %v", err)
+		}
+	}
+}
+
+func TestDownloadAndVerify(t *testing.T) {
+	private, public := testKeys(t)
+	signer, _ := NewSigner(strings.NewReader(private), "")
+	var signature bytes.Buffer
+	signer.Sign(&signature, strings.NewReader(downloadContent))
+
+	setupDownload(t)
+	defer teardown()
+	mux.HandleFunc("/dl/subject/repository/a/file.txt.asc", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(signature.Bytes())
+	})
+	mux.HandleFunc("/dl/subject/repository/a/tampered.txt", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, downloadContent+"tampered")
+	})
+	mux.HandleFunc("/dl/subject/repository/a/tampered.txt.asc", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(signature.Bytes())
+	})
+	var buf bytes.Buffer
+	err := client.DownloadAndVerify("subject", "repository", "a/file.txt", strings.NewReader(public), &buf)
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if buf.String() != downloadContent {
+		t.Errorf("downloaded %q", buf.String())
+	}
+	err = client.DownloadAndVerify("subject", "repository", "a/tampered.txt", strings.NewReader(public), ioutil.Discard)
+	if err == nil {
+		t.Errorf("expected verification error, got nil")
+	}
+}
@@ -0,0 +1,323 @@
+package bintray
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// UploadRequest describes a content upload.
+type UploadRequest struct {
+	Subject    string
+	Repository string
+	Package    string
+	Version    string
+
+	// Path is the remote path of the file in the repository, ie `org/example/1.0/example-1.0.jar`.
+	Path string
+
+	// Body is the content to upload.
+	Body io.Reader
+
+	// Length is the size of Body in bytes, if known.
+	// Zero means unknown and the content is sent chunked, unless Body is a
+	// *bytes.Reader, *bytes.Buffer or *strings.Reader.
+	Length int64
+
+	Options UploadOptions
+
+	// Progress, if not nil, is notified while the body is sent.
+	Progress ProgressReporter
+
+	// maven sends the content to the Maven API, taking the version from the path.
+	maven bool
+}
+
+// UploadOptions specifies the optional parameters of an upload.
+type UploadOptions struct {
+	// Publish publishes the file right after the upload.
+	Publish bool
+
+	// Conflict is the policy applied when a file with the same path already exists.
+	Conflict ConflictPolicy
+
+	// Explode extracts the uploaded archive in the version.
+	Explode bool
+
+	// VerifyChecksums computes the MD5, SHA1 and SHA256 of the content and
+	// verifies the SHA1 stored by Bintray after the upload.
+	VerifyChecksums bool
+
+	// ChecksumFiles uploads the `.md5`, `.sha1` and `.sha256` files next to the
+	// file, as expected in Maven repositories.
+	ChecksumFiles bool
+
+	// Signer, if not nil, signs the content locally and uploads the detached
+	// signature next to the file, with the `.asc` extension.
+	Signer *Signer
+
+	// Debian metadata, mandatory uploading to Debian repositories.
+	DebianDistribution []string
+	DebianComponent    []string
+	DebianArchitecture []string
+}
+
+// ConflictPolicy tells how to handle an upload conflicting with an existing file.
+type ConflictPolicy int
+
+const (
+	// ConflictFail returns the conflict error (see IsConflict).
+	ConflictFail ConflictPolicy = iota
+	// ConflictSkipIdentical skips the upload if the version already has a file
+	// with the same path and SHA1 of the content, checked before sending it.
+	// A different file gives the conflict error.
+	// The request body must be an io.Seeker to compute its SHA1.
+	ConflictSkipIdentical
+	// ConflictOverride replaces the existing file.
+	ConflictOverride
+)
+
+// UploadStatus is the outcome of an upload.
+type UploadStatus int
+
+const (
+	// UploadStatusUploaded means the content was uploaded.
+	UploadStatusUploaded UploadStatus = iota
+	// UploadStatusSkipped means the upload was skipped because an identical file already exists.
+	UploadStatusSkipped
+)
+
+// UploadResult reports the outcome of an upload.
+type UploadResult struct {
+	// Path is the remote path of the uploaded file.
+	Path string
+
+	// Status tells if the file was uploaded or skipped.
+	Status UploadStatus
+
+	// SignaturePath is the remote path of the uploaded signature, if any.
+	SignaturePath string
+
+	// Checksums of the uploaded content, set only if requested in the options.
+	Checksums *Checksums
+}
+
+// Upload streams the request body to `/content/:subject/:repo/:package/:version/:path`.
+// Cancelling the context aborts the upload.
+func (c *Client) Upload(ctx context.Context, r *UploadRequest) (*UploadResult, error) {
+	if r == nil || r.Subject == "" || r.Repository == "" || r.Package == "" || r.Version == "" || r.Body == nil {
+		return nil, errors.New("Upload: subject, repository, package name, version and body shouldn't be empty")
+	}
+	remotePath, err := cleanRemotePath(r.Path)
+	if err != nil {
+		return nil, err
+	}
+	uploadURL := r.url(remotePath)
+	var start int64
+	seeker, seekable := r.Body.(io.Seeker)
+	if seekable {
+		if start, err = seeker.Seek(0, io.SeekCurrent); err != nil {
+			return nil, err
+		}
+	} else if r.Options.Conflict == ConflictSkipIdentical {
+		return nil, errors.New("Upload: ConflictSkipIdentical requires a seekable body")
+	}
+	length := r.Length
+	if sized, ok := r.Body.(interface{ Len() int }); ok && length == 0 {
+		length = int64(sized.Len())
+	}
+	content := r.Body
+	var checksums *Checksums
+	var cw *checksumWriter
+	if r.Options.VerifyChecksums || r.Options.ChecksumFiles || r.Options.Conflict == ConflictSkipIdentical {
+		if seekable {
+			// computed upfront, so that identical files are skipped before sending them
+			if checksums, err = computeChecksums(r.Body); err != nil {
+				return nil, err
+			}
+			if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+				return nil, err
+			}
+		} else {
+			cw = newChecksumWriter()
+			content = io.TeeReader(content, cw)
+		}
+	}
+	if r.Options.Conflict == ConflictSkipIdentical {
+		identical, err := c.identicalRemoteFile(ctx, r.Subject, r.Repository, r.Package, r.Version, remotePath, checksums.SHA1)
+		if err != nil {
+			return nil, err
+		}
+		if identical {
+			return &UploadResult{Path: remotePath, Status: UploadStatusSkipped}, nil
+		}
+	}
+	var signature *pendingSignature
+	if r.Options.Signer != nil {
+		if content, signature, err = r.Options.Signer.signing(content, start); err != nil {
+			return nil, err
+		}
+		defer signature.finish(errors.New("upload aborted"))
+	}
+	body := newProgressReader(content, 0, length, r.Progress)
+	req, err := c.newRequestWithReader(ctx, "PUT", uploadURL, body, length)
+	if err != nil {
+		return nil, err
+	}
+	r.Options.setHeaders(req.Header)
+	if _, err = c.execute(req); err != nil {
+		return nil, err
+	}
+	if cw != nil {
+		checksums = cw.checksums()
+	}
+	result := &UploadResult{Path: remotePath, Status: UploadStatusUploaded}
+	if r.Options.VerifyChecksums || r.Options.ChecksumFiles {
+		result.Checksums = checksums
+	}
+	if r.Options.VerifyChecksums {
+		if err := c.verifyRemoteSha1(ctx, r, remotePath, checksums.SHA1); err != nil {
+			return result, err
+		}
+	}
+	if r.Options.ChecksumFiles {
+		for _, sidecar := range checksums.sidecars() {
+			if err := c.uploadSidecar(ctx, r, remotePath+sidecar[0], []byte(sidecar[1])); err != nil {
+				return result, err
+			}
+		}
+	}
+	if signature != nil {
+		asc, err := signature.finish(nil)
+		if err != nil {
+			return result, err
+		}
+		if err := c.uploadSidecar(ctx, r, remotePath+signatureExtension, asc); err != nil {
+			return result, err
+		}
+		result.SignaturePath = remotePath + signatureExtension
+	}
+	return result, nil
+}
+
+// uploadSidecar uploads a file derived from the content of r, ie its signature.
+// The sidecar belongs to the content just uploaded, so any previous one is replaced.
+func (c *Client) uploadSidecar(ctx context.Context, r *UploadRequest, path string, content []byte) error {
+	_, err := c.Upload(ctx, &UploadRequest{
+		Subject:    r.Subject,
+		Repository: r.Repository,
+		Package:    r.Package,
+		Version:    r.Version,
+		Path:       path,
+		Body:       bytes.NewReader(content),
+		Options:    UploadOptions{Publish: r.Options.Publish, Conflict: ConflictOverride},
+		maven:      r.maven,
+	})
+	return err
+}
+
+// verifyRemoteSha1 checks the SHA1 reported by Bintray for the uploaded file.
+// A file missing from the version gives an error matching IsNotFound.
+func (c *Client) verifyRemoteSha1(ctx context.Context, r *UploadRequest, path, sha1 string) error {
+	remoteSha1, err := c.remoteSha1(ctx, r.Subject, r.Repository, r.Package, r.Version, path)
+	if err != nil {
+		return err
+	}
+	if remoteSha1 == "" {
+		return fmt.Errorf("%w: uploaded file %s is not in version %s", ErrNotFound, path, r.Version)
+	}
+	if !strings.EqualFold(remoteSha1, sha1) {
+		return &ChecksumError{Path: path, Expected: sha1, Actual: remoteSha1}
+	}
+	return nil
+}
+
+// remoteSha1 returns the SHA1 of a file of the version, empty if the file is not found.
+func (c *Client) remoteSha1(ctx context.Context, subject, repository, pkg, version, path string) (string, error) {
+	files, err := c.GetFilesInfoListContext(ctx, subject, repository, pkg, version, true)
+	if err != nil {
+		return "", err
+	}
+	for _, f := range files {
+		if f.Path == path {
+			return f.Sha1, nil
+		}
+	}
+	return "", nil
+}
+
+// identicalRemoteFile reports if the version already has a file at path with the given SHA1.
+// A missing package or version has no files.
+func (c *Client) identicalRemoteFile(ctx context.Context, subject, repository, pkg, version, path, sha1 string) (bool, error) {
+	remoteSha1, err := c.remoteSha1(ctx, subject, repository, pkg, version, path)
+	if IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return remoteSha1 != "" && strings.EqualFold(remoteSha1, sha1), nil
+}
+
+// url returns the upload URL of the file at remotePath.
+func (r *UploadRequest) url(remotePath string) string {
+	query := r.Options.query()
+	u := "content/" + r.Subject + "/" + r.Repository + "/" + r.Package + "/" + r.Version + "/" + remotePath
+	if r.maven {
+		u = "maven/" + r.Subject + "/" + r.Repository + "/" + r.Package + "/" + remotePath
+		if query.Get("publish") != "" {
+			// the Maven API takes publish as matrix parameter
+			query.Del("publish")
+			u += ";publish=1"
+		}
+	}
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	return u
+}
+
+func (o UploadOptions) query() url.Values {
+	params := url.Values{}
+	if o.Publish {
+		params.Set("publish", "1")
+	}
+	if o.Conflict == ConflictOverride {
+		params.Set("override", "1")
+	}
+	if o.Explode {
+		params.Set("explode", "1")
+	}
+	return params
+}
+
+func (o UploadOptions) setHeaders(h http.Header) {
+	if len(o.DebianDistribution) > 0 {
+		h.Set("X-Bintray-Debian-Distribution", strings.Join(o.DebianDistribution, ","))
+	}
+	if len(o.DebianComponent) > 0 {
+		h.Set("X-Bintray-Debian-Component", strings.Join(o.DebianComponent, ","))
+	}
+	if len(o.DebianArchitecture) > 0 {
+		h.Set("X-Bintray-Debian-Architecture", strings.Join(o.DebianArchitecture, ","))
+	}
+}
+
+// cleanRemotePath validates a remote path, removing the leading slash.
+func cleanRemotePath(p string) (string, error) {
+	p = strings.TrimPrefix(p, "/")
+	if p == "" || strings.HasSuffix(p, "/") {
+		return "", errors.New("Upload: remote path should be a file path")
+	}
+	for _, segment := range strings.Split(p, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return "", errors.New("Upload: invalid remote path " + p)
+		}
+	}
+	return p, nil
+}
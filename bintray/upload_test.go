@@ -0,0 +1,237 @@
+package bintray
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestUpload(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/content/subject/repository/pkg/1.2/a/b/file.txt", func(w http.ResponseWriter, r *http.Request) {
+		if m := "PUT"; m != r.Method {
+			t.Errorf("Request method = %v, want %v", r.Method, m)
+		}
+		if q := r.URL.RawQuery; q != "override=1&publish=1" {
+			t.Errorf("query = %q", q)
+		}
+		testHeader(t, r, "X-Bintray-Debian-Distribution", "wheezy,jessie")
+		testHeader(t, r, "X-Bintray-Debian-Component", "main")
+		testHeader(t, r, "X-Bintray-Debian-Architecture", "i386,amd64")
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) != "file content" {
+			t.Errorf("unexpected body %q", body)
+		}
+		w.WriteHeader(201)
+		fmt.Fprint(w, `{"message":"success"}`)
+	})
+	result, err := client.Upload(context.Background(), &UploadRequest{
+		Subject:    "subject",
+		Repository: "repository",
+		Package:    "pkg",
+		Version:    "1.2",
+		Path:       "/a/b/file.txt",
+		Body:       strings.NewReader("file content"),
+		Options: UploadOptions{
+			Publish:            true,
+			Conflict:           ConflictOverride,
+			DebianDistribution: []string{"wheezy", "jessie"},
+			DebianComponent:    []string{"main"},
+			DebianArchitecture: []string{"i386", "amd64"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if result.Path != "a/b/file.txt" {
+		t.Errorf("UploadResult.Path = %q", result.Path)
+	}
+}
+
+func TestUpload_pipe(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/content/subject/repository/pkg/1.2/file.txt", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) != "streamed content" {
+			t.Errorf("unexpected body %q", body)
+		}
+		w.WriteHeader(201)
+	})
+	pr, pw := io.Pipe()
+	go func() {
+		io.WriteString(pw, "streamed ")
+		io.WriteString(pw, "content")
+		pw.Close()
+	}()
+	_, err := client.Upload(context.Background(), &UploadRequest{
+		Subject: "subject", Repository: "repository", Package: "pkg", Version: "1.2",
+		Path: "file.txt",
+		Body: pr,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+}
+
+func TestUpload_invalidPath(t *testing.T) {
+	c := NewClient(nil, "", "")
+	for _, p := range []string{"", "/", "a/", "../a", "a//b", "a/./b"} {
+		_, err := c.Upload(context.Background(), &UploadRequest{
+			Subject: "subject", Repository: "repository", Package: "pkg", Version: "1.2",
+			Path: p,
+			Body: strings.NewReader(""),
+		})
+		if err == nil {
+			t.Errorf("expected error for path %q", p)
+		}
+	}
+}
+
+func TestUpload_conflict(t *testing.T) {
+	tests := []struct {
+		policy         ConflictPolicy
+		content        string
+		expectedStatus UploadStatus
+		expectedError  bool
+	}{
+		{ConflictFail, "Hello 01!\n", UploadStatusUploaded, true},
+		{ConflictSkipIdentical, "Hello 01!\n", UploadStatusSkipped, false},
+		{ConflictSkipIdentical, "other content", UploadStatusUploaded, true},
+	}
+	for _, tt := range tests {
+		setup()
+		mux.HandleFunc("/content/subject/repository/pkg/1.2/a/01.txt", func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, `{"message":"Unable to upload files: An artifact with the path 'a/01.txt' already exists"}`, 409)
+		})
+		mux.HandleFunc("/packages/subject/repository/pkg/versions/1.2/files", func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("include_unpublished") != "1" {
+				t.Errorf("unpublished files should be included")
+			}
+			fmt.Fprint(w, `[{"name":"01.txt","path":"a/01.txt","sha1":"`+sha1Of("Hello 01!\n")+`"}]`)
+		})
+		result, err := client.Upload(context.Background(), &UploadRequest{
+			Subject: "subject", Repository: "repository", Package: "pkg", Version: "1.2",
+			Path:    "a/01.txt",
+			Body:    strings.NewReader(tt.content),
+			Options: UploadOptions{Conflict: tt.policy},
+		})
+		teardown()
+		if tt.expectedError {
+			if !IsConflict(err) {
+				t.Errorf("policy %d: expected conflict error, got %#v", tt.policy, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("policy %d: unexpected error thrown %s", tt.policy, err)
+			continue
+		}
+		if result.Status != tt.expectedStatus {
+			t.Errorf("policy %d: status %d, want %d", tt.policy, result.Status, tt.expectedStatus)
+		}
+	}
+}
+
+func TestUpload_skipIdenticalBeforeSending(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/content/subject/repository/pkg/1.2/a/01.txt", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("identical content should not be sent")
+	})
+	mux.HandleFunc("/packages/subject/repository/pkg/versions/1.2/files", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"name":"01.txt","path":"a/01.txt","sha1":"`+sha1Of("Hello 01!\n")+`"}]`)
+	})
+	result, err := client.Upload(context.Background(), &UploadRequest{
+		Subject: "subject", Repository: "repository", Package: "pkg", Version: "1.2",
+		Path:    "a/01.txt",
+		Body:    strings.NewReader("Hello 01!\n"),
+		Options: UploadOptions{Conflict: ConflictSkipIdentical},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if result.Status != UploadStatusSkipped {
+		t.Errorf("status %d, want %d", result.Status, UploadStatusSkipped)
+	}
+}
+
+func TestUpload_skipIdenticalNewVersion(t *testing.T) {
+	setup()
+	defer teardown()
+	uploaded := false
+	mux.HandleFunc("/content/subject/repository/pkg/1.2/a/01.txt", func(w http.ResponseWriter, r *http.Request) {
+		uploaded = true
+		w.WriteHeader(201)
+	})
+	mux.HandleFunc("/packages/subject/repository/pkg/versions/1.2/files", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"Version '1.2' was not found"}`, 404)
+	})
+	result, err := client.Upload(context.Background(), &UploadRequest{
+		Subject: "subject", Repository: "repository", Package: "pkg", Version: "1.2",
+		Path:    "a/01.txt",
+		Body:    strings.NewReader("Hello 01!\n"),
+		Options: UploadOptions{Conflict: ConflictSkipIdentical},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if !uploaded || result.Status != UploadStatusUploaded {
+		t.Errorf("content should be uploaded to a new version")
+	}
+}
+
+func TestUpload_skipIdenticalNotSeekable(t *testing.T) {
+	setup()
+	defer teardown()
+	pr, pw := io.Pipe()
+	defer pw.Close()
+	_, err := client.Upload(context.Background(), &UploadRequest{
+		Subject: "subject", Repository: "repository", Package: "pkg", Version: "1.2",
+		Path:    "a/01.txt",
+		Body:    pr,
+		Options: UploadOptions{Conflict: ConflictSkipIdentical},
+	})
+	if err == nil || !strings.Contains(err.Error(), "seekable") {
+		t.Errorf("expected error for a not seekable body, got %v", err)
+	}
+}
+
+func TestUploadFileWithOptions_conflict(t *testing.T) {
+	setup()
+	defer teardown()
+	content, _ := ioutil.ReadFile("testdata/01.txt")
+	var query string
+	sent := false
+	mux.HandleFunc("/content/subject/repository/pkg/1.2/01.txt", func(w http.ResponseWriter, r *http.Request) {
+		sent = true
+		query = r.URL.RawQuery
+		w.WriteHeader(201)
+	})
+	mux.HandleFunc("/packages/subject/repository/pkg/versions/1.2/files", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"name":"01.txt","path":"01.txt","sha1":"`+sha1Of(string(content))+`"}]`)
+	})
+	err := client.UploadFileWithOptions("subject", "repository", "pkg", "1.2", "", "", "testdata/01.txt", "", false, &UploadFileOptions{Conflict: ConflictSkipIdentical})
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if sent {
+		t.Errorf("identical file should not be sent")
+	}
+	sent = false
+	err = client.UploadFileWithOptions("subject", "repository", "pkg", "1.2", "", "", "testdata/01.txt", "?publish=1", false, &UploadFileOptions{Conflict: ConflictOverride})
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if !sent {
+		t.Errorf("file should be sent with ConflictOverride")
+	}
+	if query != "publish=1&override=1" {
+		t.Errorf("query = %q, want publish=1&override=1", query)
+	}
+}
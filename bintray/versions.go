@@ -0,0 +1,130 @@
+package bintray
+
+import (
+	"context"
+	"errors"
+)
+
+// Version represents a version of a Bintray package.
+type Version struct {
+	Name                     string   `json:"name"`
+	Desc                     string   `json:"desc,omitempty"`
+	Package                  string   `json:"package,omitempty"`
+	Repo                     string   `json:"repo,omitempty"`
+	Owner                    string   `json:"owner,omitempty"`
+	Labels                   []string `json:"labels,omitempty"`
+	AttributeNames           []string `json:"attribute_names,omitempty"`
+	Created                  string   `json:"created,omitempty"`
+	Updated                  string   `json:"updated,omitempty"`
+	Released                 string   `json:"released,omitempty"`
+	Published                bool     `json:"published,omitempty"`
+	Ordinal                  float64  `json:"ordinal,omitempty"`
+	VcsTag                   string   `json:"vcs_tag,omitempty"`
+	GithubReleaseNotesFile   string   `json:"github_release_notes_file,omitempty"`
+	GithubUseTagReleaseNotes bool     `json:"github_use_tag_release_notes,omitempty"`
+	RatingCount              int      `json:"rating_count,omitempty"`
+}
+
+// CreateVersionOptions are the values accepted creating a version.
+// Name is mandatory.
+type CreateVersionOptions struct {
+	Name                     string `json:"name"`
+	Desc                     string `json:"desc,omitempty"`
+	Released                 string `json:"released,omitempty"`
+	VcsTag                   string `json:"vcs_tag,omitempty"`
+	GithubReleaseNotesFile   string `json:"github_release_notes_file,omitempty"`
+	GithubUseTagReleaseNotes bool   `json:"github_use_tag_release_notes,omitempty"`
+}
+
+// UpdateVersionOptions are the values accepted updating a version.
+// Empty fields are left unchanged.
+type UpdateVersionOptions struct {
+	Desc                     string `json:"desc,omitempty"`
+	Released                 string `json:"released,omitempty"`
+	VcsTag                   string `json:"vcs_tag,omitempty"`
+	GithubReleaseNotesFile   string `json:"github_release_notes_file,omitempty"`
+	GithubUseTagReleaseNotes bool   `json:"github_use_tag_release_notes,omitempty"`
+}
+
+// CreateVersionWithOptions creates a new version for a package and returns it.
+// POST /packages/:subject/:repo/:package/versions
+func (c *Client) CreateVersionWithOptions(subject, repository, pkg string, opts *CreateVersionOptions) (*Version, error) {
+	return c.CreateVersionWithOptionsContext(context.Background(), subject, repository, pkg, opts)
+}
+
+// CreateVersionWithOptionsContext is like CreateVersionWithOptions but uses the given context for the request.
+func (c *Client) CreateVersionWithOptionsContext(ctx context.Context, subject, repository, pkg string, opts *CreateVersionOptions) (*Version, error) {
+	if opts == nil || opts.Name == "" {
+		return nil, errors.New("create version: options must contain the version name")
+	}
+	v := new(Version)
+	if err := c.executeCreateVersion(ctx, subject, repository, pkg, opts.Name, opts, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// GetVersion returns the given version of a package.
+// GET /packages/:subject/:repo/:package/versions/:version
+func (c *Client) GetVersion(subject, repository, pkg, version string) (*Version, error) {
+	return c.GetVersionContext(context.Background(), subject, repository, pkg, version)
+}
+
+// GetVersionContext is like GetVersion but uses the given context for the request.
+func (c *Client) GetVersionContext(ctx context.Context, subject, repository, pkg, version string) (*Version, error) {
+	if subject == "" || repository == "" || pkg == "" || version == "" {
+		return nil, errors.New("GetVersion: subject, repository, package name and version shouldn't be empty")
+	}
+	url := "packages/" + subject + "/" + repository + "/" + pkg + "/versions/" + version
+	v := new(Version)
+	if _, err := c.executeJSON(ctx, "GET", url, nil, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// GetLatestVersion returns the latest version of a package.
+// GET /packages/:subject/:repo/:package/versions/_latest
+func (c *Client) GetLatestVersion(subject, repository, pkg string) (*Version, error) {
+	return c.GetLatestVersionContext(context.Background(), subject, repository, pkg)
+}
+
+// GetLatestVersionContext is like GetLatestVersion but uses the given context for the request.
+func (c *Client) GetLatestVersionContext(ctx context.Context, subject, repository, pkg string) (*Version, error) {
+	if subject == "" || repository == "" || pkg == "" {
+		return nil, errors.New("GetLatestVersion: subject, repository and package name shouldn't be empty")
+	}
+	return c.GetVersionContext(ctx, subject, repository, pkg, "_latest")
+}
+
+// UpdateVersion updates the given version of a package.
+// PATCH /packages/:subject/:repo/:package/versions/:version
+func (c *Client) UpdateVersion(subject, repository, pkg, version string, opts *UpdateVersionOptions) error {
+	return c.UpdateVersionContext(context.Background(), subject, repository, pkg, version, opts)
+}
+
+// UpdateVersionContext is like UpdateVersion but uses the given context for the request.
+func (c *Client) UpdateVersionContext(ctx context.Context, subject, repository, pkg, version string, opts *UpdateVersionOptions) error {
+	if subject == "" || repository == "" || pkg == "" || version == "" || opts == nil {
+		return errors.New("UpdateVersion: subject, repository, package name, version and options shouldn't be empty")
+	}
+	url := "packages/" + subject + "/" + repository + "/" + pkg + "/versions/" + version
+	_, err := c.executeJSON(ctx, "PATCH", url, opts, nil)
+	return err
+}
+
+// DeleteVersion deletes the given version of a package and all its files.
+// DELETE /packages/:subject/:repo/:package/versions/:version
+func (c *Client) DeleteVersion(subject, repository, pkg, version string) error {
+	return c.DeleteVersionContext(context.Background(), subject, repository, pkg, version)
+}
+
+// DeleteVersionContext is like DeleteVersion but uses the given context for the request.
+func (c *Client) DeleteVersionContext(ctx context.Context, subject, repository, pkg, version string) error {
+	if subject == "" || repository == "" || pkg == "" || version == "" {
+		return errors.New("DeleteVersion: subject, repository, package name and version shouldn't be empty")
+	}
+	url := "packages/" + subject + "/" + repository + "/" + pkg + "/versions/" + version
+	_, err := c.executeJSON(ctx, "DELETE", url, nil, nil)
+	return err
+}
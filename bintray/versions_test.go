@@ -0,0 +1,109 @@
+package bintray
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+const respBodyVersion = `
+{"name":"1.1.5","desc":"This version...","package":"my-package","repo":"repo","owner":"user",
+"labels":["OSS","org.jfrog"],"attribute_names":["licenses","vcs","github"],"created":"2013-03-04T09:50:00.742Z",
+"updated":"2013-03-04T09:50:00.742Z","released":"2013-03-04T00:00:00.000Z","published":true,"ordinal":5,
+"vcs_tag":"1.1.5"}`
+
+func TestGetVersion(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/packages/subject/repository/pkg/versions/1.1.5", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, respBodyVersion)
+	})
+	v, err := client.GetVersion("subject", "repository", "pkg", "1.1.5")
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if v.Name != "1.1.5" || !v.Published || v.VcsTag != "1.1.5" || len(v.AttributeNames) != 3 {
+		t.Errorf("unexpected version %#v", v)
+	}
+}
+
+func TestGetLatestVersion(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/packages/subject/repository/pkg/versions/_latest", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, respBodyVersion)
+	})
+	v, err := client.GetLatestVersion("subject", "repository", "pkg")
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if v.Name != "1.1.5" {
+		t.Errorf("unexpected version %#v", v)
+	}
+}
+
+func TestCreateVersionWithOptions(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/packages/subject/repository/pkg/versions", func(w http.ResponseWriter, r *http.Request) {
+		if m := "POST"; m != r.Method {
+			t.Errorf("Request method = %v, want %v", r.Method, m)
+		}
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["name"] != "1.1.5" || body["vcs_tag"] != "v1.1.5" {
+			t.Errorf("unexpected request body %v", body)
+		}
+		w.WriteHeader(201)
+		fmt.Fprint(w, respBodyVersion)
+	})
+	v, err := client.CreateVersionWithOptions("subject", "repository", "pkg", &CreateVersionOptions{Name: "1.1.5", VcsTag: "v1.1.5"})
+	if err != nil {
+		t.Fatalf("unexpected error thrown %s", err)
+	}
+	if v.Name != "1.1.5" {
+		t.Errorf("unexpected version %#v", v)
+	}
+}
+
+func TestCreateVersionWithOptions_noName(t *testing.T) {
+	_, err := NewClient(nil, "", "").CreateVersionWithOptions("subject", "repository", "pkg", &CreateVersionOptions{Desc: "desc"})
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestUpdateVersion(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/packages/subject/repository/pkg/versions/1.1.5", func(w http.ResponseWriter, r *http.Request) {
+		if m := "PATCH"; m != r.Method {
+			t.Errorf("Request method = %v, want %v", r.Method, m)
+		}
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if len(body) != 1 || body["desc"] != "new desc" {
+			t.Errorf("unexpected request body %v", body)
+		}
+	})
+	err := client.UpdateVersion("subject", "repository", "pkg", "1.1.5", &UpdateVersionOptions{Desc: "new desc"})
+	if err != nil {
+		t.Errorf("unexpected error thrown %s", err)
+	}
+}
+
+func TestDeleteVersion(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/packages/subject/repository/pkg/versions/1.1.5", func(w http.ResponseWriter, r *http.Request) {
+		if m := "DELETE"; m != r.Method {
+			t.Errorf("Request method = %v, want %v", r.Method, m)
+		}
+		fmt.Fprint(w, `{"message":"success"}`)
+	})
+	err := client.DeleteVersion("subject", "repository", "pkg", "1.1.5")
+	if err != nil {
+		t.Errorf("unexpected error thrown %s", err)
+	}
+}
@@ -0,0 +1,15 @@
+module github.com/enr/go-bintray
+
+go 1.21
+
+require (
+	github.com/ProtonMail/go-crypto v1.1.6
+	github.com/ulikunitz/xz v0.5.12
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/cloudflare/circl v1.3.7 // indirect
+	golang.org/x/crypto v0.21.0 // indirect
+	golang.org/x/sys v0.18.0 // indirect
+)